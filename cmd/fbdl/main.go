@@ -7,7 +7,11 @@ import (
 	"os"
 
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/args"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/byteaddr"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/doc"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gen"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/ins"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/lock"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/reg"
 )
@@ -48,7 +52,33 @@ func main() {
 	}
 
 	if bus != nil {
-		reg.Registerify(bus, args.AddTimestamp)
+		var lck *lock.Lock
+		if args.LockFile != "" {
+			lck, err = lock.Read(args.LockFile)
+			if err != nil {
+				log.Fatalf("%v", err)
+			}
+		}
+
+		if bus.Packing == "" {
+			bus.Packing = args.Packing
+		}
+		reg.Registerify(bus, args.AddTimestamp, lck)
+
+		if args.LockFile != "" {
+			newLck := lock.Make(bus)
+			err = newLck.Write(args.LockFile)
+			if err != nil {
+				log.Fatalf("%v", err)
+			}
+		}
+	}
+
+	if args.DumpBytes != "" && bus != nil {
+		err = byteaddr.Make(bus).Write(args.DumpBytes)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
 	}
 
 	if args.DumpConsts != "" {
@@ -73,6 +103,22 @@ func main() {
 		}
 	}
 
+	if args.Cmd == "gen" {
+		err = gen.Generate(args.Target, bus, args.OutPath, gen.Options{SVG: args.SVG})
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
+	if args.Cmd == "doc" {
+		err = doc.Generate(args.Format, bus, args.OutPath)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
 	// Dump registerification results to stdout
 	jsonBytes, err := json.MarshalIndent(bus, "", "  ")
 	if err != nil {
@@ -1,19 +1,31 @@
 package args
 
 var (
+	Cmd    string // Command, empty for the default registerification results dump.
+	Target string // Generator target, valid only for the 'gen' command.
+	Format string // Documentation format, valid only for the 'doc' command.
+
+	Packing string // Default packing strategy, used by the main bus if it has no 'packing' property.
+
 	MainBus  string
 	MainFile string
 
 	Debug        bool
 	AddTimestamp bool
+	SVG          bool
 
 	DumpReg    string
 	DumpConsts string
+	DumpBytes  string // Path of the byte addresses dump.
+
+	LockFile string // Address lock file path, empty if addresses are not locked.
+
+	OutPath string
 )
 
 func isValidFlag(f string) bool {
 	flags := map[string]bool{
-		"-help": true, "-version": true, "-debug": true, "-add-timestamp": true,
+		"-help": true, "-version": true, "-debug": true, "-add-timestamp": true, "-svg": true,
 	}
 	if _, ok := flags[f]; ok {
 		return true
@@ -23,10 +35,37 @@ func isValidFlag(f string) bool {
 
 func isValidParam(p string) bool {
 	params := map[string]bool{
-		"-main": true, "-r": true, "-c": true,
+		"-main": true, "-r": true, "-c": true, "-o": true, "-format": true, "-lock": true, "-packing": true, "-byte-addr": true,
 	}
 	if _, ok := params[p]; ok {
 		return true
 	}
 	return false
 }
+
+func isValidTarget(t string) bool {
+	targets := map[string]bool{
+		"go": true, "python": true, "rust": true, "wavedrom": true,
+	}
+	if _, ok := targets[t]; ok {
+		return true
+	}
+	return false
+}
+
+// packingStrategies maps packing strategy names to 'packing' property values.
+var packingStrategies = map[string]string{
+	"compact":           "Compact",
+	"one-per-register":  "One Per Register",
+	"natural-alignment": "Natural Alignment",
+}
+
+func isValidFormat(f string) bool {
+	formats := map[string]bool{
+		"md": true, "html": true,
+	}
+	if _, ok := formats[f]; ok {
+		return true
+	}
+	return false
+}
@@ -10,6 +10,18 @@ Version: %s
 
 Usage:
   fbdl [flags] [parameters] /path/to/main/fbd/file
+  fbdl gen <target> [flags] [parameters] /path/to/main/fbd/file
+  fbdl doc [flags] [parameters] /path/to/main/fbd/file
+
+  The first form dumps registerification results to stdout.
+  The second form generates register access code for the given target.
+  The third form generates register map documentation.
+
+Targets:
+  go        Go package with one struct per block.
+  python    Python package with one class per block.
+  rust      Rust no_std crate with one module per block.
+  wavedrom  WaveDrom reg JSON bit-field diagram for every occupied register.
 
 Flags:
   -help           Display help.
@@ -18,10 +30,31 @@ Flags:
   -add-timestamp  Add bus generation timestamp.
                   The timestamp is not included in the ID calculation.
                   The timestamp is always placed at the end of the bus address space.
+  -svg            Render diagrams also as SVG images, valid only for the wavedrom target.
 
 Parameters:
   -main name  Name of the main bus. Useful for testbenches.
   -c [path]   Dump packages constants to a file (default path is const.json).
+  -byte-addr [path]
+              Dump byte addresses and byte lanes of the registerified bus to a file
+              (default path is byte-addr.json). The register byte size is the bus width
+              rounded up to full bytes and to the power of 2.
+  -lock [path]
+              Use address lock file (default path is fbdl.lock).
+              If the file exists, addresses of unchanged functionalities, blocks, blackboxes
+              and memories are restored from it. New functionalities are placed only in free addresses.
+              The file is updated after the registerification.
+  -o path     Output path for generated files (default path is fbdl).
+              The base name of the path is used as the generated package or crate name.
+              For the doc command it is the path of the documentation file
+              (default path is fbdl.md or fbdl.html).
+  -format fmt Documentation format, md or html (default format is md).
+  -packing strategy
+              Packing strategy of the main bus without the 'packing' property
+              (default strategy is compact). Subblocks inherit the strategy.
+              compact            Place functionalities in any fitting gap.
+              one-per-register   Start each functionality at a new register.
+              natural-alignment  Place functionalities in gaps only at naturally aligned bits.
 `
 
 func printHelp() {
@@ -21,6 +21,8 @@ func Parse() {
 			printVersion()
 		case "-add-timestamp":
 			AddTimestamp = true
+		case "-svg":
+			SVG = true
 		default:
 			panic(fmt.Sprintf("unhandled flag '%s', implement me", f))
 		}
@@ -37,6 +39,10 @@ func Parse() {
 				DumpReg = "reg.json"
 			case "-c":
 				DumpConsts = "const.json"
+			case "-lock":
+				LockFile = "fbdl.lock"
+			case "-byte-addr":
+				DumpBytes = "byte-addr.json"
 			default:
 				maybeVal = false
 				val = true
@@ -46,8 +52,25 @@ func Parse() {
 		}
 	}
 
-	for i, arg := range os.Args[1:] {
-		if i == len(os.Args)-2 {
+	argv := os.Args[1:]
+
+	if len(argv) > 0 && argv[0] == "gen" {
+		Cmd = "gen"
+		if len(argv) < 2 {
+			log.Fatalf("missing generator target")
+		}
+		Target = argv[1]
+		if !isValidTarget(Target) {
+			log.Fatalf("invalid generator target '%s'", Target)
+		}
+		argv = argv[2:]
+	} else if len(argv) > 0 && argv[0] == "doc" {
+		Cmd = "doc"
+		argv = argv[1:]
+	}
+
+	for i, arg := range argv {
+		if i == len(argv)-1 {
 			switch arg {
 			case "-help":
 				printHelp()
@@ -69,6 +92,16 @@ func Parse() {
 			switch param {
 			case "-main":
 				MainBus = arg
+			case "-o":
+				OutPath = arg
+			case "-format":
+				Format = arg
+			case "-packing":
+				p, ok := packingStrategies[arg]
+				if !ok {
+					log.Fatalf("invalid packing strategy '%s'", arg)
+				}
+				Packing = p
 			default:
 				panic(fmt.Sprintf("unhandled param '%s', implement me", param))
 			}
@@ -88,6 +121,10 @@ func Parse() {
 				DumpReg = arg
 			case "-c":
 				DumpConsts = arg
+			case "-lock":
+				LockFile = arg
+			case "-byte-addr":
+				DumpBytes = arg
 			}
 		} else {
 			if isValidFlag(arg) {
@@ -107,4 +144,20 @@ func Parse() {
 	if MainFile == "" {
 		log.Fatalf("missing path to main file")
 	}
+
+	if Cmd == "gen" && OutPath == "" {
+		OutPath = "fbdl"
+	}
+
+	if Cmd == "doc" {
+		if Format == "" {
+			Format = "md"
+		}
+		if !isValidFormat(Format) {
+			log.Fatalf("invalid documentation format '%s'", Format)
+		}
+		if OutPath == "" {
+			OutPath = "fbdl." + Format
+		}
+	}
 }
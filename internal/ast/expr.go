@@ -36,6 +36,15 @@ type (
 		Args []Expr
 	}
 
+	// Conditional expression, "X if C else Y"
+	CondExpr struct {
+		X    Expr
+		If   tok.If
+		C    Expr
+		Else tok.Else
+		Y    Expr
+	}
+
 	List struct {
 		LBracket tok.LBracket
 		Xs       []Expr
@@ -46,6 +55,14 @@ type (
 		Name tok.Token
 	}
 
+	// Index or slice expression, "X[Idx]"
+	IndexExpr struct {
+		X        Expr
+		LBracket tok.LBracket
+		Idx      Expr
+		RBracket tok.RBracket
+	}
+
 	QualIdent struct {
 		Name tok.Token
 	}
@@ -90,12 +107,18 @@ func (b Bool) Tok() tok.Token { return b.X }
 func (c Call) expr()          {}
 func (c Call) Tok() tok.Token { return c.Name }
 
+func (ce CondExpr) expr()          {}
+func (ce CondExpr) Tok() tok.Token { return tok.Join(ce.X.Tok(), ce.Y.Tok()) }
+
 func (l List) expr()          {}
 func (l List) Tok() tok.Token { return tok.Join(l.LBracket, l.RBracket) }
 
 func (i Ident) expr()          {}
 func (i Ident) Tok() tok.Token { return i.Name }
 
+func (ie IndexExpr) expr()          {}
+func (ie IndexExpr) Tok() tok.Token { return tok.Join(ie.X.Tok(), ie.RBracket) }
+
 func (qi QualIdent) expr()          {}
 func (qi QualIdent) Tok() tok.Token { return qi.Name }
 
@@ -165,10 +188,26 @@ func buildExpr(ctx *context, leftOp tok.Operator) (Expr, error) {
 		return expr, err
 	}
 
+	for {
+		if _, ok := ctx.tok().(tok.LBracket); !ok {
+			break
+		}
+		expr, err = buildIndexExpr(ctx, expr)
+		if err != nil {
+			return expr, err
+		}
+	}
+
 	for {
 		var rightOp tok.Operator
-		if op, ok := ctx.tok().(tok.Operator); ok {
+		if _, ok := ctx.tok().(tok.Neg); ok {
+			// Negation is a unary operator only.
+			return expr, nil
+		} else if op, ok := ctx.tok().(tok.Operator); ok {
 			rightOp = op
+		} else if _, ok := ctx.tok().(tok.If); ok && leftOp == nil {
+			// Conditional expression has the lowest precedence.
+			return buildCondExpr(ctx, expr)
 		} else {
 			return expr, nil
 		}
@@ -344,6 +383,52 @@ tokenLoop:
 	return call, nil
 }
 
+func buildIndexExpr(ctx *context, x Expr) (IndexExpr, error) {
+	ie := IndexExpr{X: x, LBracket: ctx.tok().(tok.LBracket)}
+	ctx.idx++
+
+	idx, err := buildExpr(ctx, nil)
+	if err != nil {
+		return ie, err
+	}
+	ie.Idx = idx
+
+	if rb, ok := ctx.tok().(tok.RBracket); ok {
+		ie.RBracket = rb
+		ctx.idx++
+	} else {
+		return ie, unexpected(ctx.tok(), "']'")
+	}
+
+	return ie, nil
+}
+
+func buildCondExpr(ctx *context, x Expr) (CondExpr, error) {
+	ce := CondExpr{X: x, If: ctx.tok().(tok.If)}
+	ctx.idx++
+
+	c, err := buildExpr(ctx, nil)
+	if err != nil {
+		return ce, err
+	}
+	ce.C = c
+
+	if e, ok := ctx.tok().(tok.Else); ok {
+		ce.Else = e
+		ctx.idx++
+	} else {
+		return ce, unexpected(ctx.tok(), "'else'")
+	}
+
+	y, err := buildExpr(ctx, nil)
+	if err != nil {
+		return ce, err
+	}
+	ce.Y = y
+
+	return ce, nil
+}
+
 func buildUnaryExpr(ctx *context) (UnaryExpr, error) {
 	op := ctx.tok().(tok.Operator)
 	un := UnaryExpr{Op: op}
@@ -256,3 +256,71 @@ func TestBuildBinaryExpr(t *testing.T) {
 		t.Fatalf("%v", err)
 	}
 }
+
+func TestBuildCondExpr(t *testing.T) {
+	toks, _ := tok.Parse([]byte("A + 1 if C else 2"), "")
+	want := CondExpr{
+		X: BinaryExpr{
+			X: Ident{Name: toks[0]}, Op: toks[1].(tok.Operator), Y: Int{toks[2].(tok.Int)},
+		},
+		If:   toks[3].(tok.If),
+		C:    Ident{Name: toks[4]},
+		Else: toks[5].(tok.Else),
+		Y:    Int{toks[6].(tok.Int)},
+	}
+	ctx := context{toks: toks}
+	got, err := buildExpr(&ctx, nil)
+	err = checkExpr(ctx, 7, got, want, err)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	toks, _ = tok.Parse([]byte("1 if A else 2 if B else 3"), "")
+	want = CondExpr{
+		X:    Int{toks[0].(tok.Int)},
+		If:   toks[1].(tok.If),
+		C:    Ident{Name: toks[2]},
+		Else: toks[3].(tok.Else),
+		Y: CondExpr{
+			X:    Int{toks[4].(tok.Int)},
+			If:   toks[5].(tok.If),
+			C:    Ident{Name: toks[6]},
+			Else: toks[7].(tok.Else),
+			Y:    Int{toks[8].(tok.Int)},
+		},
+	}
+	ctx.idx = 0
+	ctx.toks = toks
+	got, err = buildExpr(&ctx, nil)
+	err = checkExpr(ctx, 9, got, want, err)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestBuildIndexExpr(t *testing.T) {
+	toks, _ := tok.Parse([]byte("L[1:2][0] + 1"), "")
+	want := BinaryExpr{
+		X: IndexExpr{
+			X: IndexExpr{
+				X:        Ident{Name: toks[0]},
+				LBracket: toks[1].(tok.LBracket),
+				Idx: BinaryExpr{
+					X: Int{toks[2].(tok.Int)}, Op: toks[3].(tok.Operator), Y: Int{toks[4].(tok.Int)},
+				},
+				RBracket: toks[5].(tok.RBracket),
+			},
+			LBracket: toks[6].(tok.LBracket),
+			Idx:      Int{toks[7].(tok.Int)},
+			RBracket: toks[8].(tok.RBracket),
+		},
+		Op: toks[9].(tok.Operator),
+		Y:  Int{toks[10].(tok.Int)},
+	}
+	ctx := context{toks: toks}
+	got, err := buildExpr(&ctx, nil)
+	err = checkExpr(ctx, 11, got, want, err)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+}
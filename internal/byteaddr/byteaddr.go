@@ -0,0 +1,134 @@
+// Package byteaddr implements byte address view of the registerified bus.
+//
+// Registerification results use register addresses, where consecutive registers
+// have consecutive addresses. Interconnects and drivers, however, usually use byte addresses.
+// The byte address view is dumped alongside the registerification results,
+// so that users and generators do not have to reimplement the conversion.
+package byteaddr
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// Block is the byte address view of the block.
+//
+// Accesses and strobes addresses are relative to the block start address.
+// Access keys are functionality names, params and returns names are prefixed with the proc or stream name,
+// and irq enable accesses are named with the ".enable" suffix, for example "p.a" or "i.enable".
+// Strobe keys are functionality names followed by the strobe name, for example "p call".
+type Block struct {
+	AddrSpace  types.SingleRange
+	Accesses   map[string]types.ByteAccess
+	Strobes    map[string]int64
+	Blackboxes map[string]types.SingleRange
+	Memories   map[string]types.SingleRange
+}
+
+// Map is the byte address view of the bus.
+// Block keys are hierarchical names separated with '.', for example "main.blk".
+type Map struct {
+	RegByteCount int64 // Number of bytes occupied by a single register.
+	Blocks       map[string]Block
+}
+
+// Make makes byte address view of the registerified bus.
+func Make(bus *fn.Block) Map {
+	m := Map{
+		RegByteCount: types.RegByteCount(bus.Width),
+		Blocks:       map[string]Block{},
+	}
+	m.addBlock(bus, bus.Name, bus.Width)
+	return m
+}
+
+func (m *Map) addBlock(blk *fn.Block, path string, width int64) {
+	b := Block{
+		AddrSpace:  types.ByteRange(blk.AddrSpace, width),
+		Accesses:   map[string]types.ByteAccess{},
+		Strobes:    map[string]int64{},
+		Blackboxes: map[string]types.SingleRange{},
+		Memories:   map[string]types.SingleRange{},
+	}
+
+	strobe := func(name string, addr int64) {
+		b.Strobes[name] = types.ByteAddr(addr, width)
+	}
+
+	for _, c := range blk.Configs {
+		b.Accesses[c.Name] = c.Access.ByteAccess()
+	}
+	for _, i := range blk.Irqs {
+		b.Accesses[i.Name] = i.Access.ByteAccess()
+		if i.AddEnable {
+			b.Accesses[i.Name+".enable"] = i.EnableAccess.ByteAccess()
+		}
+		if i.ClearAddr != nil {
+			strobe(i.Name+" clear", *i.ClearAddr)
+		}
+	}
+	for _, mask := range blk.Masks {
+		b.Accesses[mask.Name] = mask.Access.ByteAccess()
+	}
+	for _, p := range blk.Procs {
+		for _, pp := range p.Params {
+			b.Accesses[p.Name+"."+pp.Name] = pp.Access.ByteAccess()
+		}
+		for _, r := range p.Returns {
+			b.Accesses[p.Name+"."+r.Name] = r.Access.ByteAccess()
+		}
+		if p.CallAddr != nil {
+			strobe(p.Name+" call", *p.CallAddr)
+		}
+		if p.ExitAddr != nil {
+			strobe(p.Name+" exit", *p.ExitAddr)
+		}
+	}
+	for _, s := range blk.Statics {
+		b.Accesses[s.Name] = s.Access.ByteAccess()
+	}
+	for _, s := range blk.Statuses {
+		b.Accesses[s.Name] = s.Access.ByteAccess()
+	}
+	for _, s := range blk.Streams {
+		for _, p := range s.Params {
+			b.Accesses[s.Name+"."+p.Name] = p.Access.ByteAccess()
+		}
+		for _, r := range s.Returns {
+			b.Accesses[s.Name+"."+r.Name] = r.Access.ByteAccess()
+		}
+		strobe(s.Name+" strobe", s.StbAddr)
+	}
+
+	for _, bb := range blk.Blackboxes {
+		b.Blackboxes[bb.Name] = types.ByteRange(bb.AddrSpace, width)
+	}
+	for _, mem := range blk.Memories {
+		b.Memories[mem.Name] = types.ByteRange(mem.AddrSpace, width)
+	}
+
+	m.Blocks[path] = b
+
+	for _, sb := range blk.Subblocks {
+		m.addBlock(sb, path+"."+sb.Name, width)
+	}
+}
+
+// Write writes the byte address view to the file.
+func (m Map) Write(path string) error {
+	bytes, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("dump byte addresses: %v", err)
+	}
+
+	err = os.WriteFile(path, append(bytes, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("dump byte addresses: %v", err)
+	}
+
+	return nil
+}
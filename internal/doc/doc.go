@@ -0,0 +1,638 @@
+// Package doc implements register map documentation generator.
+//
+// The documentation is generated from the registerified bus.
+// Supported formats are GitHub flavored Markdown and self-contained HTML.
+package doc
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/regmap"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// writer is the interface implemented by the documentation formats.
+// Methods accepting text accept already formatted (escaped) text.
+type writer interface {
+	esc(s string) string
+	code(s string) string
+	link(anchor, text string) string
+	lineBreak() string
+
+	begin(title string)
+	end()
+	heading(level int, anchor, text string)
+	para(text string)
+	list(items []string, depths []int, ordered bool)
+	table(header []string, rows [][]string)
+
+	String() string
+}
+
+// Generate generates the bus documentation in given format and writes it to the file under path.
+func Generate(format string, bus *fn.Block, path string) error {
+	var w writer
+	switch format {
+	case "md":
+		w = &markdown{}
+	case "html":
+		w = &htmlDoc{}
+	default:
+		panic(fmt.Sprintf("unhandled documentation format '%s'", format))
+	}
+
+	g := generator{w: w, busWidth: bus.Width}
+	g.genBus(bus)
+
+	err := os.WriteFile(path, []byte(w.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("generate doc: %v", err)
+	}
+
+	return nil
+}
+
+type generator struct {
+	w        writer
+	busWidth int64
+}
+
+// block is a block with its path.
+type block struct {
+	blk  *fn.Block
+	path []string
+}
+
+func (b block) name() string {
+	return strings.Join(b.path, ".")
+}
+
+func (b block) anchor() string {
+	return "blk-" + strings.Join(b.path, "-")
+}
+
+func regAnchor(blk block, addr int64) string {
+	return fmt.Sprintf("%s-reg-%d", blk.anchor(), addr)
+}
+
+func hex(v int64) string {
+	return fmt.Sprintf("0x%X", v)
+}
+
+func addrRange(r types.SingleRange) string {
+	return fmt.Sprintf("[%s:%s]", hex(r.Start), hex(r.End))
+}
+
+// flatten returns list of all blocks in the pre-order, and list of their depths.
+func flatten(blk *fn.Block, path []string, depth int) ([]block, []int) {
+	blks := []block{{blk: blk, path: path}}
+	depths := []int{depth}
+	for _, sb := range blk.Subblocks {
+		sp := make([]string, len(path), len(path)+1)
+		copy(sp, path)
+		b, d := flatten(sb, append(sp, sb.Name), depth+1)
+		blks = append(blks, b...)
+		depths = append(depths, d...)
+	}
+	return blks, depths
+}
+
+func (g *generator) doc(doc string) string {
+	lines := strings.Split(doc, "\n")
+	for i, l := range lines {
+		lines[i] = g.w.esc(l)
+	}
+	return strings.Join(lines, g.w.lineBreak())
+}
+
+func (g *generator) genBus(bus *fn.Block) {
+	w := g.w
+
+	w.begin(bus.Name + " register map")
+	if bus.Doc != "" {
+		w.para(g.doc(bus.Doc))
+	}
+	w.para(fmt.Sprintf(
+		"Bus width: %d bits. Address space: %s, %d registers.%sAll addresses are register addresses, not byte addresses.",
+		bus.Width, w.code(addrRange(bus.AddrSpace)), bus.Sizes.Aligned, w.lineBreak(),
+	))
+
+	blks, depths := flatten(bus, []string{bus.Name}, 0)
+
+	w.heading(2, "hierarchy", "Block hierarchy")
+	items := []string{}
+	for _, b := range blks {
+		item := w.link(b.anchor(), w.code(b.name())) + " " + w.code(addrRange(b.blk.AddrSpace))
+		if b.blk.IsArray {
+			item += fmt.Sprintf(" × %d, stride %d", b.blk.Count, b.blk.Sizes.Aligned)
+		}
+		items = append(items, item)
+	}
+	w.list(items, depths, false)
+
+	for _, b := range blks {
+		g.genBlock(b)
+	}
+
+	w.end()
+}
+
+func (g *generator) genBlock(b block) {
+	w := g.w
+	blk := b.blk
+
+	w.heading(2, b.anchor(), "Block "+w.code(b.name()))
+	if blk.Doc != "" {
+		w.para(g.doc(blk.Doc))
+	}
+
+	info := fmt.Sprintf(
+		"Address space: %s, aligned size %d, own size %d.%s"+
+			"Functionalities and registers addresses are relative to the block start address.",
+		w.code(addrRange(blk.AddrSpace)), blk.Sizes.Aligned, blk.Sizes.Own, w.lineBreak(),
+	)
+	if blk.IsArray {
+		info += fmt.Sprintf(
+			"%sArray of %d blocks, the address space of the block with index i starts at %s + i × %d.",
+			w.lineBreak(), blk.Count, hex(blk.AddrSpace.Start), blk.Sizes.Aligned,
+		)
+	}
+	w.para(info)
+
+	if len(blk.Subblocks) > 0 {
+		items := []string{}
+		for _, sb := range blk.Subblocks {
+			sp := append(append([]string{}, b.path...), sb.Name)
+			sbb := block{blk: sb, path: sp}
+			items = append(items, w.link(sbb.anchor(), w.code(sbb.name())))
+		}
+		w.para("Subblocks:")
+		w.list(items, make([]int, len(items)), false)
+	}
+
+	if len(blk.Blackboxes) > 0 {
+		items := []string{}
+		for _, bb := range blk.Blackboxes {
+			item := w.code(bb.Name) + " " + w.code(addrRange(bb.AddrSpace))
+			if bb.IsArray {
+				item += fmt.Sprintf(" × %d, stride %d", bb.Count, bb.Sizes.Aligned)
+			}
+			item += fmt.Sprintf(", size %d", bb.Size)
+			if bb.Doc != "" {
+				item += " – " + g.doc(bb.Doc)
+			}
+			items = append(items, item)
+		}
+		w.para("Blackboxes:")
+		w.list(items, make([]int, len(items)), false)
+	}
+
+	if len(blk.Memories) > 0 {
+		items := []string{}
+		for _, mem := range blk.Memories {
+			item := w.code(mem.Name) + " " + w.code(addrRange(mem.AddrSpace))
+			if mem.IsArray {
+				item += fmt.Sprintf(" × %d, stride %d", mem.Count, mem.Sizes.Aligned)
+			}
+			item += fmt.Sprintf(
+				", %d × %d bits, %s, read latency %d", mem.Size, mem.Width, strings.ToLower(mem.Access), mem.ReadLatency,
+			)
+			if mem.ByteWriteEnable {
+				item += ", byte write enable"
+			}
+			if mem.Doc != "" {
+				item += " – " + g.doc(mem.Doc)
+			}
+			items = append(items, item)
+		}
+		w.para("Memories:")
+		w.list(items, make([]int, len(items)), false)
+	}
+
+	g.genFunctionalities(b)
+	g.genRegisters(b)
+	g.genSequences(b)
+}
+
+// row is a single row of the block functionalities table.
+type row struct {
+	addr  int64
+	bit   int64
+	cells []string
+}
+
+func (g *generator) bits(acs types.Access) string {
+	if acs.StartAddr == acs.EndAddr {
+		return fmt.Sprintf("[%d:%d]", acs.EndBit, acs.StartBit)
+	}
+	return fmt.Sprintf(
+		"[%d:%d] … [%d:%d]",
+		acs.StartBit+acs.StartRegWidth-1, acs.StartBit,
+		acs.EndBit, acs.EndBit-acs.EndRegWidth+1,
+	)
+}
+
+func (g *generator) addrs(acs types.Access) string {
+	r := acs.AddrRange()
+	if r.Start == r.End {
+		return g.w.code(hex(r.Start))
+	}
+	return g.w.code(hex(r.Start) + "–" + hex(r.End))
+}
+
+func (g *generator) values(vals ...string) string {
+	strs := []string{}
+	for i := 0; i < len(vals); i += 2 {
+		if vals[i+1] != "" {
+			strs = append(strs, vals[i]+": "+g.w.code(vals[i+1]))
+		}
+	}
+	return strings.Join(strs, g.w.lineBreak())
+}
+
+// enumValues appends named values of the functionality to the values cell.
+func (g *generator) enumValues(values string, enum types.Enum) string {
+	strs := []string{}
+	if values != "" {
+		strs = append(strs, values)
+	}
+	for _, ev := range enum {
+		strs = append(strs, g.w.code(fmt.Sprintf("%s = %d", ev.Name, ev.Value)))
+	}
+	return strings.Join(strs, g.w.lineBreak())
+}
+
+// accessModeType returns the functionality type with the access mode appended,
+// if the access mode is not the default one, for example "config, write only".
+func accessModeType(typ, mode string) string {
+	if mode == "Read Write" {
+		return typ
+	}
+	return typ + ", " + strings.ToLower(mode)
+}
+
+func rangeStr(r types.Range) string {
+	switch r := r.(type) {
+	case types.SingleRange:
+		return fmt.Sprintf("[%d:%d]", r.Start, r.End)
+	case types.ArrayRange:
+		strs := []string{}
+		for _, sr := range r {
+			strs = append(strs, fmt.Sprintf("%d:%d", sr.Start, sr.End))
+		}
+		return "[" + strings.Join(strs, ", ") + "]"
+	}
+	return ""
+}
+
+func (g *generator) dataRow(name, typ string, f fn.Func, acs types.Access, value, rng string) row {
+	count := ""
+	if f.IsArray {
+		count = fmt.Sprintf("%d", f.Count)
+	}
+	return row{
+		addr: acs.StartAddr,
+		bit:  acs.StartBit,
+		cells: []string{
+			g.w.code(name), typ, g.addrs(acs), g.w.code(g.bits(acs)),
+			fmt.Sprintf("%d", acs.ItemWidth), count, value, rng, g.doc(f.Doc),
+		},
+	}
+}
+
+// withFields appends bit fields of the item to the row description.
+func (g *generator) withFields(fields []*fn.Field, r row) row {
+	if len(fields) == 0 {
+		return r
+	}
+	strs := []string{"Fields:"}
+	for _, f := range fields {
+		bits := fmt.Sprintf("[%d]", f.Offset)
+		if f.Width > 1 {
+			bits = fmt.Sprintf("[%d:%d]", f.EndBit(), f.Offset)
+		}
+		item := g.w.code(f.Name) + " " + g.w.code(bits)
+		if f.Doc != "" {
+			item += " – " + g.doc(f.Doc)
+		}
+		strs = append(strs, item)
+	}
+	desc := &r.cells[len(r.cells)-1]
+	if *desc != "" {
+		*desc += g.w.lineBreak()
+	}
+	*desc += strings.Join(strs, g.w.lineBreak())
+	return r
+}
+
+// bufferRow returns row for a proc or stream.
+// The addr is the start address of the params or returns buffer.
+func (g *generator) bufferRow(name, typ string, f fn.Func, addr int64, values []string) row {
+	return row{
+		addr: addr,
+		bit:  -1,
+		cells: []string{
+			g.w.code(name), typ, "", "", "", "", strings.Join(values, g.w.lineBreak()), "", g.doc(f.Doc),
+		},
+	}
+}
+
+func (g *generator) genFunctionalities(b block) {
+	w := g.w
+	blk := b.blk
+
+	rows := []row{}
+	for _, c := range blk.Configs {
+		rng := ""
+		if c.Range != nil {
+			rng = w.code(rangeStr(c.Range))
+		}
+		rows = append(rows, g.withFields(c.Fields, g.dataRow(
+			c.Name, accessModeType("config", c.AccessMode), c.Func, c.Access,
+			g.enumValues(g.values("init", string(c.InitValue), "reset", string(c.ResetValue), "read", string(c.ReadValue)), c.Values),
+			rng,
+		)))
+	}
+	for _, i := range blk.Irqs {
+		value := fmt.Sprintf("clear: %s", strings.ToLower(i.Clear))
+		if i.ClearAddr != nil {
+			value += fmt.Sprintf(" at %s", w.code(hex(*i.ClearAddr)))
+		}
+		rows = append(rows, g.dataRow(i.Name, "irq", i.Func, i.Access, value, ""))
+		if i.AddEnable {
+			enable := i.Func
+			enable.Doc = "Enable of the " + i.Name + " irq."
+			rows = append(rows, g.dataRow(
+				i.Name+".enable", "irq enable", enable, i.EnableAccess,
+				g.values("init", string(i.EnableInitValue), "reset", string(i.EnableResetValue)), "",
+			))
+		}
+	}
+	for _, m := range blk.Masks {
+		rows = append(rows, g.dataRow(
+			m.Name, accessModeType("mask", m.AccessMode), m.Func, m.Access,
+			g.values("init", string(m.InitValue), "reset", string(m.ResetValue), "read", string(m.ReadValue)), "",
+		))
+	}
+	for _, p := range blk.Procs {
+		values := []string{}
+		addr := int64(0)
+		if p.CallAddr != nil {
+			addr = *p.CallAddr
+			values = append(values, "call: "+w.code(hex(*p.CallAddr)))
+		}
+		if p.ExitAddr != nil {
+			addr = *p.ExitAddr
+			values = append(values, "exit: "+w.code(hex(*p.ExitAddr)))
+		}
+		if p.Delay != nil {
+			values = append(values, "delay: "+w.code(fmt.Sprintf("%d s %d ns", p.Delay.S, p.Delay.Ns)))
+		}
+		if len(p.Params) > 0 {
+			addr = p.Params[0].Access.StartAddr
+		} else if len(p.Returns) > 0 {
+			addr = p.Returns[0].Access.StartAddr
+		}
+		rows = append(rows, g.bufferRow(p.Name, "proc", p.Func, addr, values))
+		for _, pp := range p.Params {
+			rows = append(rows, g.dataRow(p.Name+"."+pp.Name, "param", pp.Func, pp.Access, g.enumValues("", pp.Values), g.paramRange(pp)))
+		}
+		for _, r := range p.Returns {
+			rows = append(rows, g.dataRow(p.Name+"."+r.Name, "return", r.Func, r.Access, "", ""))
+		}
+	}
+	for _, s := range blk.Statics {
+		rows = append(rows, g.withFields(s.Fields, g.dataRow(
+			s.Name, "static", s.Func, s.Access,
+			g.values("init", string(s.InitValue), "reset", string(s.ResetValue), "read", string(s.ReadValue)), "",
+		)))
+	}
+	for _, s := range blk.Statuses {
+		rows = append(rows, g.withFields(s.Fields, g.dataRow(
+			s.Name, "status", s.Func, s.Access, g.enumValues(g.values("read", string(s.ReadValue)), s.Values), "",
+		)))
+	}
+	for _, s := range blk.Streams {
+		rows = append(rows, g.bufferRow(
+			s.Name, "stream", s.Func, s.StartAddr(), []string{"strobe: " + w.code(hex(s.StbAddr))},
+		))
+		for _, p := range s.Params {
+			rows = append(rows, g.dataRow(s.Name+"."+p.Name, "param", p.Func, p.Access, g.enumValues("", p.Values), g.paramRange(p)))
+		}
+		for _, r := range s.Returns {
+			rows = append(rows, g.dataRow(s.Name+"."+r.Name, "return", r.Func, r.Access, "", ""))
+		}
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+
+	// Procs and streams rows are placed before their params and returns,
+	// as they start at the same address, and their bit is -1.
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].addr != rows[j].addr {
+			return rows[i].addr < rows[j].addr
+		}
+		return rows[i].bit < rows[j].bit
+	})
+
+	w.heading(3, b.anchor()+"-fns", "Functionalities")
+	cells := [][]string{}
+	for _, r := range rows {
+		cells = append(cells, r.cells)
+	}
+	w.table(
+		[]string{"Name", "Type", "Address", "Bits", "Width", "Count", "Values", "Range", "Description"},
+		cells,
+	)
+}
+
+func (g *generator) paramRange(p *fn.Param) string {
+	if p.Range == nil {
+		return ""
+	}
+	return g.w.code(rangeStr(p.Range))
+}
+
+func (g *generator) genRegisters(b block) {
+	w := g.w
+	regs := regmap.Make(b.blk)
+	if len(regs) == 0 {
+		return
+	}
+
+	w.heading(3, b.anchor()+"-regs", "Registers")
+
+	items := []string{}
+	for _, r := range regs {
+		items = append(items, w.link(regAnchor(b, r.Addr), w.code(hex(r.Addr))))
+	}
+	w.para(strings.Join(items, ", "))
+
+	shared := map[int64][]string{}
+	for _, sr := range b.blk.SharedRegs {
+		shared[sr.Addr] = sr.Funcs
+	}
+
+	for _, r := range regs {
+		w.heading(4, regAnchor(b, r.Addr), "Register "+w.code(hex(r.Addr)))
+		if len(r.Strobes) > 0 {
+			strobes := []string{}
+			for _, s := range r.Strobes {
+				strobes = append(strobes, w.code(s))
+			}
+			w.para("Access generates strobe: " + strings.Join(strobes, ", ") + ".")
+		}
+		if funcs, ok := shared[r.Addr]; ok {
+			names := []string{}
+			for _, f := range funcs {
+				names = append(names, w.code(f))
+			}
+			w.para("Register is shared by writable functionalities " + strings.Join(names, ", ") + ", writes require read-modify-write.")
+		}
+
+		// Rows are placed from the most significant bit.
+		rows := [][]string{}
+		gaps := r.Gaps(g.busWidth)
+		fi, gi := len(r.Fields)-1, len(gaps)-1
+		for fi >= 0 || gi >= 0 {
+			if gi < 0 || (fi >= 0 && r.Fields[fi].Bit > gaps[gi].Start) {
+				f := r.Fields[fi]
+				rows = append(rows, []string{
+					w.code(fmt.Sprintf("[%d:%d]", f.EndBit(), f.Bit)), w.code(f.Label()), f.Type,
+				})
+				fi--
+			} else {
+				gap := gaps[gi]
+				rows = append(rows, []string{
+					w.code(fmt.Sprintf("[%d:%d]", gap.End, gap.Start)), "—", "unused",
+				})
+				gi--
+			}
+		}
+		w.table([]string{"Bits", "Field", "Type"}, rows)
+	}
+}
+
+// accessLabels returns labels of fields placed under given addresses, that belong to the accesses.
+func accessLabels(regs map[int64]regmap.Register, addr int64, names map[string]bool) []string {
+	labels := []string{}
+	for _, f := range regs[addr].Fields {
+		if names[f.Name] {
+			labels = append(labels, f.Label())
+		}
+	}
+	return labels
+}
+
+// sequence returns steps of the buffer access.
+// Registers are accessed in increasing address order, so the strobe
+// register is always accessed as the last one.
+func (g *generator) sequence(
+	regs map[int64]regmap.Register, op string, names map[string]bool, accesses []types.Access, stbAddr *int64, stb string,
+) []string {
+	addrs := map[int64]bool{}
+	for _, acs := range accesses {
+		for a := acs.StartAddr; a <= acs.EndAddr; a++ {
+			addrs[a] = true
+		}
+	}
+	if stbAddr != nil {
+		addrs[*stbAddr] = true
+	}
+
+	sorted := []int64{}
+	for a := range addrs {
+		sorted = append(sorted, a)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	steps := []string{}
+	for _, a := range sorted {
+		step := fmt.Sprintf("%s register %s", op, g.w.code(hex(a)))
+		labels := accessLabels(regs, a, names)
+		if len(labels) > 0 {
+			codes := []string{}
+			for _, l := range labels {
+				codes = append(codes, g.w.code(l))
+			}
+			step += " (" + strings.Join(codes, ", ") + ")"
+		}
+		if stbAddr != nil && a == *stbAddr {
+			step += ", this access generates the " + stb + " strobe"
+		}
+		steps = append(steps, step+".")
+	}
+	return steps
+}
+
+func (g *generator) genSequences(b block) {
+	w := g.w
+	blk := b.blk
+	if len(blk.Procs) == 0 && len(blk.Streams) == 0 {
+		return
+	}
+
+	regs := map[int64]regmap.Register{}
+	for _, r := range regmap.Make(blk) {
+		regs[r.Addr] = r
+	}
+
+	w.heading(3, b.anchor()+"-seqs", "Call sequences")
+
+	for _, p := range blk.Procs {
+		params := map[string]bool{}
+		paramsAcs := []types.Access{}
+		for _, pp := range p.Params {
+			params[p.Name+"."+pp.Name] = true
+			paramsAcs = append(paramsAcs, pp.Access)
+		}
+		rets := map[string]bool{}
+		retsAcs := []types.Access{}
+		for _, r := range p.Returns {
+			rets[p.Name+"."+r.Name] = true
+			retsAcs = append(retsAcs, r.Access)
+		}
+
+		steps := []string{}
+		if len(p.Params) > 0 || p.CallAddr != nil {
+			steps = append(steps, g.sequence(regs, "Write", params, paramsAcs, p.CallAddr, "call")...)
+		}
+		if p.Delay != nil {
+			steps = append(steps, fmt.Sprintf("Wait %d s %d ns.", p.Delay.S, p.Delay.Ns))
+		}
+		if len(p.Returns) > 0 || p.ExitAddr != nil {
+			steps = append(steps, g.sequence(regs, "Read", rets, retsAcs, p.ExitAddr, "exit")...)
+		}
+
+		w.heading(4, b.anchor()+"-proc-"+p.Name, "Proc "+w.code(p.Name))
+		w.list(steps, make([]int, len(steps)), true)
+	}
+
+	for _, s := range blk.Streams {
+		names := map[string]bool{}
+		accesses := []types.Access{}
+		op := "Write"
+		for _, p := range s.Params {
+			names[s.Name+"."+p.Name] = true
+			accesses = append(accesses, p.Access)
+		}
+		if s.IsUpstream() {
+			op = "Read"
+			for _, r := range s.Returns {
+				names[s.Name+"."+r.Name] = true
+				accesses = append(accesses, r.Access)
+			}
+		}
+		stbAddr := s.StbAddr
+		steps := g.sequence(regs, op, names, accesses, &stbAddr, "stream")
+
+		w.heading(4, b.anchor()+"-stream-"+s.Name, "Stream "+w.code(s.Name))
+		w.list(steps, make([]int, len(steps)), true)
+	}
+}
@@ -0,0 +1,107 @@
+package doc
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+const style = `body { font-family: sans-serif; max-width: 1200px; margin: auto; padding: 0 1em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #999; padding: 0.2em 0.6em; text-align: left; vertical-align: top; }
+th { background: #eee; }
+code { font-family: monospace; }
+`
+
+// htmlDoc implements writer producing self-contained HTML document.
+type htmlDoc struct {
+	b strings.Builder
+}
+
+func (h *htmlDoc) esc(s string) string {
+	return html.EscapeString(s)
+}
+
+func (h *htmlDoc) code(s string) string {
+	return "<code>" + html.EscapeString(s) + "</code>"
+}
+
+func (h *htmlDoc) link(anchor, text string) string {
+	return fmt.Sprintf("<a href=\"#%s\">%s</a>", anchor, text)
+}
+
+func (h *htmlDoc) lineBreak() string {
+	return "<br>"
+}
+
+func (h *htmlDoc) begin(title string) {
+	fmt.Fprintf(
+		&h.b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n",
+		h.esc(title), style,
+	)
+	fmt.Fprintf(&h.b, "<h1>%s</h1>\n", h.esc(title))
+}
+
+func (h *htmlDoc) end() {
+	h.b.WriteString("</body>\n</html>\n")
+}
+
+func (h *htmlDoc) heading(level int, anchor, text string) {
+	fmt.Fprintf(&h.b, "<h%[1]d id=\"%[2]s\">%[3]s</h%[1]d>\n", level, anchor, text)
+}
+
+func (h *htmlDoc) para(text string) {
+	fmt.Fprintf(&h.b, "<p>%s</p>\n", text)
+}
+
+func (h *htmlDoc) list(items []string, depths []int, ordered bool) {
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+
+	// Nested lists are placed within the parent list item.
+	depth := -1
+	for i, item := range items {
+		if depths[i] > depth {
+			for ; depth < depths[i]; depth++ {
+				fmt.Fprintf(&h.b, "<%s>\n", tag)
+			}
+		} else {
+			h.b.WriteString("</li>\n")
+			for ; depth > depths[i]; depth-- {
+				fmt.Fprintf(&h.b, "</%s>\n</li>\n", tag)
+			}
+		}
+		fmt.Fprintf(&h.b, "<li>%s", item)
+	}
+	if depth >= 0 {
+		h.b.WriteString("</li>\n")
+	}
+	for ; depth > 0; depth-- {
+		fmt.Fprintf(&h.b, "</%s>\n</li>\n", tag)
+	}
+	if len(items) > 0 {
+		fmt.Fprintf(&h.b, "</%s>\n", tag)
+	}
+}
+
+func (h *htmlDoc) table(header []string, rows [][]string) {
+	h.b.WriteString("<table>\n<tr>")
+	for _, c := range header {
+		fmt.Fprintf(&h.b, "<th>%s</th>", c)
+	}
+	h.b.WriteString("</tr>\n")
+	for _, row := range rows {
+		h.b.WriteString("<tr>")
+		for _, c := range row {
+			fmt.Fprintf(&h.b, "<td>%s</td>", c)
+		}
+		h.b.WriteString("</tr>\n")
+	}
+	h.b.WriteString("</table>\n")
+}
+
+func (h *htmlDoc) String() string {
+	return h.b.String()
+}
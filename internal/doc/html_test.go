@@ -0,0 +1,32 @@
+package doc
+
+import (
+	"testing"
+)
+
+func TestHTMLList(t *testing.T) {
+	var tests = []struct {
+		items  []string
+		depths []int
+		want   string
+	}{
+		{[]string{"a", "b"}, []int{0, 0}, "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{
+			[]string{"a", "b", "c"}, []int{0, 1, 0},
+			"<ul>\n<li>a<ul>\n<li>b</li>\n</ul>\n</li>\n<li>c</li>\n</ul>\n",
+		},
+		{
+			[]string{"a", "b", "c"}, []int{0, 1, 2},
+			"<ul>\n<li>a<ul>\n<li>b<ul>\n<li>c</li>\n</ul>\n</li>\n</ul>\n</li>\n</ul>\n",
+		},
+	}
+
+	for i, test := range tests {
+		h := htmlDoc{}
+		h.list(test.items, test.depths, false)
+		got := h.String()
+		if got != test.want {
+			t.Errorf("[%d]: got %q, want %q", i, got, test.want)
+		}
+	}
+}
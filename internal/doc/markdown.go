@@ -0,0 +1,73 @@
+package doc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// markdown implements writer producing GitHub flavored Markdown.
+type markdown struct {
+	b strings.Builder
+}
+
+func (md *markdown) esc(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "|", `\|`,
+		"<", "&lt;", ">", "&gt;", "[", `\[`, "]", `\]`, "#", `\#`,
+	)
+	return r.Replace(s)
+}
+
+func (md *markdown) code(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+func (md *markdown) link(anchor, text string) string {
+	return fmt.Sprintf("[%s](#%s)", text, anchor)
+}
+
+func (md *markdown) lineBreak() string {
+	return "<br>"
+}
+
+func (md *markdown) begin(title string) {
+	fmt.Fprintf(&md.b, "# %s\n", md.esc(title))
+}
+
+func (md *markdown) end() {}
+
+func (md *markdown) heading(level int, anchor, text string) {
+	fmt.Fprintf(&md.b, "\n<a id=\"%s\"></a>\n\n%s %s\n", anchor, strings.Repeat("#", level), text)
+}
+
+func (md *markdown) para(text string) {
+	fmt.Fprintf(&md.b, "\n%s\n", text)
+}
+
+func (md *markdown) list(items []string, depths []int, ordered bool) {
+	md.b.WriteString("\n")
+	n := 1
+	for i, item := range items {
+		if ordered {
+			fmt.Fprintf(&md.b, "%s%d. %s\n", strings.Repeat("   ", depths[i]), n, item)
+			n++
+		} else {
+			fmt.Fprintf(&md.b, "%s- %s\n", strings.Repeat("  ", depths[i]), item)
+		}
+	}
+}
+
+func (md *markdown) table(header []string, rows [][]string) {
+	md.b.WriteString("\n| " + strings.Join(header, " | ") + " |\n")
+	md.b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, row := range rows {
+		md.b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+}
+
+func (md *markdown) String() string {
+	return md.b.String()
+}
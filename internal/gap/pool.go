@@ -1,5 +1,9 @@
 package gap
 
+import "sort"
+
+// Pool stores gaps sorted in increasing width order,
+// so that the narrowest fitting gap is always taken first.
 type Pool struct {
 	singles []Single
 	arrays  []Array
@@ -8,46 +12,98 @@ type Pool struct {
 func (p *Pool) Add(g Gap) {
 	switch g := g.(type) {
 	case Single:
-		if len(p.singles) == 0 {
-			p.singles = append(p.singles, g)
-			return
-		}
-		for i, s := range p.singles {
-			if g.Width() < s.Width() {
-				p.singles = append(p.singles[:i+1], p.singles[i:]...)
-				p.singles[i] = g
-				return
-			}
-			p.singles = append(p.singles, g)
-		}
+		i := sort.Search(len(p.singles), func(i int) bool { return g.Width() < p.singles[i].Width() })
+		p.singles = append(p.singles[:i], append([]Single{g}, p.singles[i:]...)...)
 	case Array:
-		if len(p.arrays) == 0 {
-			p.arrays = append(p.arrays, g)
+		// Array gap within single register is a single gap.
+		if g.StartAddr == g.EndAddr {
+			p.Add(Single{Addr: g.StartAddr, StartBit: g.StartBit, EndBit: g.EndBit, WriteSafe: g.WriteSafe})
 			return
 		}
-		for i, a := range p.arrays {
-			if g.Width() < a.Width() {
-				p.arrays = append(p.arrays[:i+1], p.arrays[i:]...)
-				p.arrays[i] = g
+		i := sort.Search(len(p.arrays), func(i int) bool { return g.Width() < p.arrays[i].Width() })
+		p.arrays = append(p.arrays[:i], append([]Array{g}, p.arrays[i:]...)...)
+	}
+}
 
-				return
-			}
-		}
+// alignBit returns the first bit not lower than bit, which is a multiple of align.
+func alignBit(bit, align int64) int64 {
+	if bit%align == 0 {
+		return bit
 	}
+	return bit + align - bit%align
 }
 
-// GetSingle returns Single gap from the Pool if gap with desired parameters is found in the pool.
+// GetSingle returns Single gap of the given width from the Pool if such gap is found in the pool.
 // In such a case second return is true, otherwise second return is false.
+// The start bit of the returned gap is a multiple of align.
+// Array gaps are used if there is no fitting single gap.
 //
-// writeSafe parameter indicates whether gap has to be write safe.
-// If writeSafe = true, then gap must be write safe.
-// if writeSafe = false, then gap can be write safe, but does not have to.
-func (p *Pool) GetSingle(width int64, writeSafe bool) (Single, bool) {
+// writable parameter indicates whether the gap is taken by a writable functionality.
+// Writable functionality can be placed only in a write safe gap,
+// and the remaining parts of the taken gap are not write safe anymore.
+func (p *Pool) GetSingle(width, align int64, writable bool) (Single, bool) {
 	for i, s := range p.singles {
-		if (s.Width() >= width) && (!writeSafe || (writeSafe && s.WriteSafe)) {
-			p.singles = append(p.singles[:i], p.singles[i+1:]...)
-			return s, true
+		start := alignBit(s.StartBit, align)
+		if start+width-1 > s.EndBit || (writable && !s.WriteSafe) {
+			continue
 		}
+
+		p.singles = append(p.singles[:i], p.singles[i+1:]...)
+		ws := s.WriteSafe && !writable
+		if start > s.StartBit {
+			p.Add(Single{Addr: s.Addr, StartBit: s.StartBit, EndBit: start - 1, WriteSafe: ws})
+		}
+		if start+width-1 < s.EndBit {
+			p.Add(Single{Addr: s.Addr, StartBit: start + width, EndBit: s.EndBit, WriteSafe: ws})
+		}
+
+		return Single{Addr: s.Addr, StartBit: start, EndBit: start + width - 1, WriteSafe: ws}, true
 	}
+
+	if a, ok := p.GetArray(1, width, align, writable); ok {
+		return Single{Addr: a.StartAddr, StartBit: a.StartBit, EndBit: a.EndBit, WriteSafe: a.WriteSafe}, true
+	}
+
 	return Single{}, false
 }
+
+// GetArray returns Array gap spanning count registers, of the given width, from the Pool,
+// if such gap is found in the pool.
+// In such a case second return is true, otherwise second return is false.
+// The start bit of the returned gap is a multiple of align.
+//
+// writable parameter has the same meaning as for the GetSingle.
+func (p *Pool) GetArray(count, width, align int64, writable bool) (Array, bool) {
+	for i, a := range p.arrays {
+		start := alignBit(a.StartBit, align)
+		if a.EndAddr-a.StartAddr+1 < count || start+width-1 > a.EndBit || (writable && !a.WriteSafe) {
+			continue
+		}
+
+		p.arrays = append(p.arrays[:i], p.arrays[i+1:]...)
+		endAddr := a.StartAddr + count - 1
+		ws := a.WriteSafe && !writable
+		if start > a.StartBit {
+			p.Add(Array{StartAddr: a.StartAddr, EndAddr: endAddr, StartBit: a.StartBit, EndBit: start - 1, WriteSafe: ws})
+		}
+		if start+width-1 < a.EndBit {
+			p.Add(Array{StartAddr: a.StartAddr, EndAddr: endAddr, StartBit: start + width, EndBit: a.EndBit, WriteSafe: ws})
+		}
+		// Registers not taken by the array keep the whole gap.
+		if endAddr < a.EndAddr {
+			p.Add(Array{StartAddr: endAddr + 1, EndAddr: a.EndAddr, StartBit: a.StartBit, EndBit: a.EndBit, WriteSafe: a.WriteSafe})
+		}
+
+		return Array{StartAddr: a.StartAddr, EndAddr: endAddr, StartBit: start, EndBit: start + width - 1, WriteSafe: ws}, true
+	}
+
+	return Array{}, false
+}
+
+// Copy returns a deep copy of the pool.
+func (p *Pool) Copy() Pool {
+	return Pool{
+		singles: append([]Single(nil), p.singles...),
+		arrays:  append([]Array(nil), p.arrays...),
+	}
+}
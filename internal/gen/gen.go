@@ -0,0 +1,35 @@
+// Package gen implements generators producing register access code
+// from registerification results.
+package gen
+
+import (
+	"fmt"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gen/golang"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gen/python"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gen/rust"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gen/wavedrom"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+// Options are generators options.
+type Options struct {
+	SVG bool // Render SVG images, valid only for the wavedrom target.
+}
+
+// Generate generates code for the given target and writes it to the path.
+// The bus must be already registerified.
+func Generate(target string, bus *fn.Block, path string, opts Options) error {
+	switch target {
+	case "go":
+		return golang.Generate(bus, path)
+	case "python":
+		return python.Generate(bus, path)
+	case "rust":
+		return rust.Generate(bus, path)
+	case "wavedrom":
+		return wavedrom.Generate(bus, path, opts.SVG)
+	default:
+		panic(fmt.Sprintf("unhandled generator target '%s'", target))
+	}
+}
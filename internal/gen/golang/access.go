@@ -0,0 +1,305 @@
+package golang
+
+// accessGo is the content of the access.go file.
+// The file implements generic packing and unpacking of functionalities
+// values, so that the generated methods only have to describe accesses.
+const accessGo = `// Code generated by fbdl. DO NOT EDIT.
+
+package %s
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Interface is the bus transport interface.
+//
+// Addresses are register addresses, not byte addresses.
+type Interface interface {
+	Read(addr int64) (uint64, error)
+	Write(addr int64, data uint64) error
+}
+
+// chunk describes part of an item placed within single register.
+// The item bits [shift + width - 1:shift] are placed in the register
+// under address addr at bits [bit + width - 1:bit].
+type chunk struct {
+	addr  int64
+	bit   int64
+	width int64
+	shift int64
+}
+
+// access describes placement of a functionality within registers.
+// Addresses are relative to the start address of the enclosing block.
+type access struct {
+	regWidth  int64
+	itemWidth int64
+	items     [][]chunk // Chunks of each item.
+}
+
+type unsigned interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// arg is a proc or stream param argument.
+type arg struct {
+	acs  access
+	vals []*big.Int
+}
+
+// chunks returns chunks of the item with given index.
+func (a access) chunks(idx int) ([]chunk, error) {
+	if idx < 0 || idx >= len(a.items) {
+		return nil, fmt.Errorf("index %%d out of range [0:%%d]", idx, len(a.items)-1)
+	}
+	return a.items[idx], nil
+}
+
+func mask(width int64) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+	return (uint64(1) << width) - 1
+}
+
+func bigMask(width int64) *big.Int {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(width))
+	return m.Sub(m, big.NewInt(1))
+}
+
+// writeReg writes part of the register.
+// Registers only partially occupied by the part are read-modify-written.
+func writeReg(iface Interface, base int64, rw int64, c chunk, part uint64) error {
+	data := part << c.bit
+	if c.width != rw {
+		old, err := iface.Read(base + c.addr)
+		if err != nil {
+			return err
+		}
+		data |= old &^ (mask(c.width) << c.bit) & mask(rw)
+	}
+	return iface.Write(base+c.addr, data)
+}
+
+func readUint(iface Interface, base int64, a access, idx int) (uint64, error) {
+	chunks, err := a.chunks(idx)
+	if err != nil {
+		return 0, err
+	}
+
+	v := uint64(0)
+	for _, c := range chunks {
+		data, err := iface.Read(base + c.addr)
+		if err != nil {
+			return 0, err
+		}
+		v |= ((data >> c.bit) & mask(c.width)) << c.shift
+	}
+
+	return v, nil
+}
+
+func writeUint(iface Interface, base int64, a access, idx int, v uint64) error {
+	if v > mask(a.itemWidth) {
+		return fmt.Errorf("value %%d out of range [0:%%d]", v, mask(a.itemWidth))
+	}
+
+	chunks, err := a.chunks(idx)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range chunks {
+		err := writeReg(iface, base, a.regWidth, c, (v>>c.shift)&mask(c.width))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readBig(iface Interface, base int64, a access, idx int) (*big.Int, error) {
+	chunks, err := a.chunks(idx)
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(big.Int)
+	for _, c := range chunks {
+		data, err := iface.Read(base + c.addr)
+		if err != nil {
+			return nil, err
+		}
+		part := new(big.Int).SetUint64((data >> c.bit) & mask(c.width))
+		v.Or(v, part.Lsh(part, uint(c.shift)))
+	}
+
+	return v, nil
+}
+
+func bigPart(v *big.Int, c chunk) uint64 {
+	part := new(big.Int).Rsh(v, uint(c.shift))
+	return part.And(part, bigMask(c.width)).Uint64()
+}
+
+func checkBig(v *big.Int, width int64) error {
+	if v.Sign() < 0 || v.Cmp(bigMask(width)) > 0 {
+		return fmt.Errorf("value %%v out of range [0:%%v]", v, bigMask(width))
+	}
+	return nil
+}
+
+func writeBig(iface Interface, base int64, a access, idx int, v *big.Int) error {
+	err := checkBig(v, a.itemWidth)
+	if err != nil {
+		return err
+	}
+
+	chunks, err := a.chunks(idx)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range chunks {
+		err := writeReg(iface, base, a.regWidth, c, bigPart(v, c))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toBigs[T unsigned](vs []T) []*big.Int {
+	bigs := make([]*big.Int, len(vs))
+	for i, v := range vs {
+		bigs[i] = new(big.Int).SetUint64(uint64(v))
+	}
+	return bigs
+}
+
+func fromBigs[T unsigned](bigs []*big.Int, vs []T) {
+	for i, b := range bigs {
+		vs[i] = T(b.Uint64())
+	}
+}
+
+// writeBuffer writes params buffer.
+// Registers are written in increasing address order, so the write
+// to the strobe address (call address) is always the last one.
+// Negative strobe address means there is no strobe address.
+func writeBuffer(iface Interface, base int64, args []arg, stbAddr int64) error {
+	regs := map[int64]uint64{}
+	addrs := []int64{}
+	add := func(addr int64, data uint64) {
+		if _, ok := regs[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+		regs[addr] |= data
+	}
+
+	for _, a := range args {
+		for idx, v := range a.vals {
+			err := checkBig(v, a.acs.itemWidth)
+			if err != nil {
+				return err
+			}
+			chunks, err := a.acs.chunks(idx)
+			if err != nil {
+				return err
+			}
+			for _, c := range chunks {
+				add(c.addr, bigPart(v, c)<<c.bit)
+			}
+		}
+	}
+	if stbAddr >= 0 {
+		add(stbAddr, 0)
+	}
+
+	for i := 1; i < len(addrs); i++ {
+		for j := i; j > 0 && addrs[j] < addrs[j-1]; j-- {
+			addrs[j], addrs[j-1] = addrs[j-1], addrs[j]
+		}
+	}
+
+	for _, addr := range addrs {
+		err := iface.Write(base+addr, regs[addr])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readBuffer reads returns buffer.
+// Registers are read in increasing address order, so the read
+// from the strobe address (exit address) is always the last one.
+// Negative strobe address means there is no strobe address.
+func readBuffer(iface Interface, base int64, rets []access, stbAddr int64) ([][]*big.Int, error) {
+	start, end := stbAddr, stbAddr
+	for _, r := range rets {
+		for _, chunks := range r.items {
+			for _, c := range chunks {
+				if start < 0 || c.addr < start {
+					start = c.addr
+				}
+				if c.addr > end {
+					end = c.addr
+				}
+			}
+		}
+	}
+
+	regs := map[int64]uint64{}
+	for addr := start; addr <= end; addr++ {
+		data, err := iface.Read(base + addr)
+		if err != nil {
+			return nil, err
+		}
+		regs[addr] = data
+	}
+
+	vals := make([][]*big.Int, len(rets))
+	for i, r := range rets {
+		for _, chunks := range r.items {
+			v := new(big.Int)
+			for _, c := range chunks {
+				part := new(big.Int).SetUint64((regs[c.addr] >> c.bit) & mask(c.width))
+				v.Or(v, part.Lsh(part, uint(c.shift)))
+			}
+			vals[i] = append(vals[i], v)
+		}
+	}
+
+	return vals, nil
+}
+
+// call calls proc.
+// Negative call or exit address means there is no such address.
+// Negative delay means proc has no delay.
+func call(
+	iface Interface, base int64, args []arg, rets []access, callAddr, exitAddr int64, delay time.Duration,
+) ([][]*big.Int, error) {
+	if len(args) > 0 || callAddr >= 0 {
+		err := writeBuffer(iface, base, args, callAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if delay >= 0 {
+		time.Sleep(delay)
+	}
+
+	if len(rets) > 0 || exitAddr >= 0 {
+		return readBuffer(iface, base, rets, exitAddr)
+	}
+
+	return nil, nil
+}
+`
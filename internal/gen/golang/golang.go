@@ -0,0 +1,588 @@
+// Package golang implements Go register access package generator.
+//
+// The generated package contains one struct per block.
+// All structs access registers via the Interface type, which must be
+// implemented by the user for the underlying bus transport.
+package golang
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// Identifiers that must not be used as function parameter names.
+var reserved = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true,
+	"default": true, "defer": true, "else": true, "fallthrough": true, "for": true,
+	"func": true, "go": true, "goto": true, "if": true, "import": true,
+	"interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+	// Identifiers used by the generated code.
+	"blk": true, "err": true, "vals": true, "big": true, "time": true, "nil": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true, "int": true,
+	"int64": true, "error": true, "access": true, "arg": true, "call": true,
+}
+
+// Generate generates Go package for the bus and writes it into the directory under path.
+// The package name is the base name of the path.
+func Generate(bus *fn.Block, path string) error {
+	if bus.Width > 64 {
+		return fmt.Errorf(
+			"generate go: bus width %d is greater than 64, go generator supports only buses with width up to 64",
+			bus.Width,
+		)
+	}
+
+	pkgName := packageName(path)
+
+	err := os.MkdirAll(path, 0755)
+	if err != nil {
+		return fmt.Errorf("generate go: %v", err)
+	}
+
+	err = writeFile(filepath.Join(path, "access.go"), fmt.Sprintf(accessGo, pkgName))
+	if err != nil {
+		return fmt.Errorf("generate go: %v", err)
+	}
+
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "// Code generated by fbdl. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "// Package %s provides access to the '%s' bus registers.\n", pkgName, bus.Name)
+	fmt.Fprintf(&b, "package %s\n", pkgName)
+	if usesBig(bus) {
+		b.WriteString("\nimport \"math/big\"\n")
+	}
+
+	root := structName([]string{bus.Name})
+	fmt.Fprintf(&b, "\n// New%[1]s returns new %[1]s accessing registers via the iface.\n", root)
+	fmt.Fprintf(&b, "func New%[1]s(iface Interface) *%[1]s {\n", root)
+	fmt.Fprintf(&b, "\treturn new%s(iface, %d)\n}\n", root, bus.AddrSpace.Start)
+
+	genBlock(&b, bus, []string{bus.Name})
+
+	err = writeFile(filepath.Join(path, pkgName+".go"), b.String())
+	if err != nil {
+		return fmt.Errorf("generate go: %v", err)
+	}
+
+	return nil
+}
+
+// writeFile formats the Go source and writes it to the file.
+func writeFile(path string, src string) error {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("format %s: %v", path, err)
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
+
+// usesBig returns true if any functionality within the block or its subblocks
+// requires *big.Int as value type.
+func usesBig(blk *fn.Block) bool {
+	widths := []int64{}
+	for _, c := range blk.Configs {
+		widths = append(widths, c.Width)
+	}
+	for _, m := range blk.Masks {
+		widths = append(widths, m.Width)
+	}
+	for _, s := range blk.Statics {
+		widths = append(widths, s.Width)
+	}
+	for _, s := range blk.Statuses {
+		widths = append(widths, s.Width)
+	}
+	for _, p := range blk.Procs {
+		for _, pp := range p.Params {
+			widths = append(widths, pp.Width)
+		}
+		for _, r := range p.Returns {
+			widths = append(widths, r.Width)
+		}
+	}
+	for _, s := range blk.Streams {
+		for _, p := range s.Params {
+			widths = append(widths, p.Width)
+		}
+		for _, r := range s.Returns {
+			widths = append(widths, r.Width)
+		}
+	}
+
+	for _, w := range widths {
+		if w > 64 {
+			return true
+		}
+	}
+
+	for _, sb := range blk.Subblocks {
+		if usesBig(sb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func packageName(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	name = strings.Map(func(r rune) rune {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || ('0' <= name[0] && name[0] <= '9') || reserved[name] {
+		name = "fbdl" + name
+	}
+	return name
+}
+
+// exported returns exported Go identifier for the FBDL name.
+func exported(name string) string {
+	b := strings.Builder{}
+	for _, w := range strings.Split(name, "_") {
+		if w == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// local returns Go identifier for the function parameter.
+func local(name string) string {
+	if reserved[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// structName returns struct name for the block with given path.
+func structName(path []string) string {
+	b := strings.Builder{}
+	for _, p := range path {
+		b.WriteString(exported(p))
+	}
+	return b.String()
+}
+
+// subPath returns path of the subblock with given name.
+func subPath(path []string, name string) []string {
+	sp := make([]string, len(path), len(path)+1)
+	copy(sp, path)
+	return append(sp, name)
+}
+
+// goType returns the smallest Go type capable of holding value of given width.
+func goType(width int64) string {
+	switch {
+	case width <= 8:
+		return "uint8"
+	case width <= 16:
+		return "uint16"
+	case width <= 32:
+		return "uint32"
+	case width <= 64:
+		return "uint64"
+	default:
+		return "*big.Int"
+	}
+}
+
+// valType returns type of the functionality value.
+func valType(f fn.Func, width int64) string {
+	if f.IsArray {
+		return fmt.Sprintf("[%d]%s", f.Count, goType(width))
+	}
+	return goType(width)
+}
+
+func zero(f fn.Func, width int64) string {
+	if f.IsArray {
+		return valType(f, width) + "{}"
+	}
+	if width > 64 {
+		return "nil"
+	}
+	return "0"
+}
+
+// access returns access literal with chunks of all items.
+// Chunks are generated from the access, so the generated code does not have to know access types.
+func access(acs types.Access) string {
+	items := make([]string, acs.ItemCount)
+	for i := range acs.ItemCount {
+		chunks := []string{}
+		for _, c := range acs.Chunks(i) {
+			chunks = append(chunks, fmt.Sprintf("{%d, %d, %d, %d}", c.Addr, c.Bit, c.Width, c.Shift))
+		}
+		items[i] = "{" + strings.Join(chunks, ", ") + "}"
+	}
+	return fmt.Sprintf("access{%d, %d, [][]chunk{%s}}", acs.RegWidth, acs.ItemWidth, strings.Join(items, ", "))
+}
+
+func optAddr(addr *int64) string {
+	if addr == nil {
+		return "-1"
+	}
+	return fmt.Sprintf("%d", *addr)
+}
+
+func delay(d *types.Time) string {
+	if d == nil {
+		return "-1"
+	}
+	return fmt.Sprintf("%d", d.S*1000000000+d.Ns)
+}
+
+func docComment(b *strings.Builder, name string, doc string, ind string) {
+	if doc == "" {
+		return
+	}
+	lines := strings.Split(doc, "\n")
+	fmt.Fprintf(b, "%s// %s %s\n", ind, name, lines[0])
+	for _, l := range lines[1:] {
+		if l == "" {
+			fmt.Fprintf(b, "%s//\n", ind)
+		} else {
+			fmt.Fprintf(b, "%s// %s\n", ind, l)
+		}
+	}
+}
+
+func genBlock(b *strings.Builder, blk *fn.Block, path []string) {
+	name := structName(path)
+
+	b.WriteString("\n")
+	docComment(b, name, blk.Doc, "")
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	b.WriteString("\tiface Interface\n\tbase  int64\n")
+	if len(blk.Subblocks) > 0 {
+		b.WriteString("\n")
+	}
+	for _, sb := range blk.Subblocks {
+		docComment(b, exported(sb.Name), sb.Doc, "\t")
+		typ := "*" + structName(subPath(path, sb.Name))
+		if sb.IsArray {
+			typ = fmt.Sprintf("[%d]%s", sb.Count, typ)
+		}
+		fmt.Fprintf(b, "\t%s %s\n", exported(sb.Name), typ)
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\nfunc new%[1]s(iface Interface, base int64) *%[1]s {\n", name)
+	fmt.Fprintf(b, "\tblk := &%s{iface: iface, base: base}\n", name)
+	for _, sb := range blk.Subblocks {
+		offset := sb.AddrSpace.Start - blk.AddrSpace.Start
+		sbName := structName(subPath(path, sb.Name))
+		if sb.IsArray {
+			fmt.Fprintf(b, "\tfor i := range blk.%s {\n", exported(sb.Name))
+			fmt.Fprintf(
+				b, "\t\tblk.%s[i] = new%s(iface, base+%d+int64(i)*%d)\n\t}\n",
+				exported(sb.Name), sbName, offset, sb.Sizes.Aligned,
+			)
+		} else {
+			fmt.Fprintf(b, "\tblk.%s = new%s(iface, base+%d)\n", exported(sb.Name), sbName, offset)
+		}
+	}
+	b.WriteString("\treturn blk\n}\n")
+
+	for _, c := range blk.Configs {
+		if c.IsReadable() {
+			genRead(b, name, c.Func, c.Width, c.Access)
+		}
+		if c.IsWritable() {
+			genWrite(b, name, c.Func, c.Width, c.Access)
+		}
+		genEnum(b, name+exported(c.Name), c.Values)
+		genFields(b, name+exported(c.Name), c.Width, c.Fields)
+	}
+	for _, i := range blk.Irqs {
+		genIrq(b, name, i)
+	}
+	for _, m := range blk.Masks {
+		if m.IsReadable() {
+			genRead(b, name, m.Func, m.Width, m.Access)
+		}
+		if m.IsWritable() {
+			genWrite(b, name, m.Func, m.Width, m.Access)
+		}
+	}
+	for _, s := range blk.Statics {
+		genRead(b, name, s.Func, s.Width, s.Access)
+		genFields(b, name+exported(s.Name), s.Width, s.Fields)
+	}
+	for _, s := range blk.Statuses {
+		genRead(b, name, s.Func, s.Width, s.Access)
+		genEnum(b, name+exported(s.Name), s.Values)
+		genFields(b, name+exported(s.Name), s.Width, s.Fields)
+	}
+	for _, p := range blk.Procs {
+		genProc(b, name, p)
+		genParamEnums(b, name+exported(p.Name), p.Params)
+	}
+	for _, s := range blk.Streams {
+		genStream(b, name, s)
+		genParamEnums(b, name+exported(s.Name), s.Params)
+	}
+
+	for _, sb := range blk.Subblocks {
+		genBlock(b, sb, subPath(path, sb.Name))
+	}
+}
+
+// genRead generates read method for a data functionality.
+func genRead(b *strings.Builder, blkName string, f fn.Func, width int64, acs types.Access) {
+	method := "Read" + exported(f.Name)
+	typ := goType(width)
+
+	b.WriteString("\n")
+	docComment(b, method, f.Doc, "")
+	if f.IsArray {
+		fmt.Fprintf(b, "func (blk *%s) %s(idx int) (%s, error) {\n", blkName, method, typ)
+	} else {
+		fmt.Fprintf(b, "func (blk *%s) %s() (%s, error) {\n", blkName, method, typ)
+	}
+
+	idx := "0"
+	if f.IsArray {
+		idx = "idx"
+	}
+
+	if width > 64 {
+		fmt.Fprintf(b, "\treturn readBig(blk.iface, blk.base, %s, %s)\n}\n", access(acs), idx)
+		return
+	}
+	fmt.Fprintf(b, "\tv, err := readUint(blk.iface, blk.base, %s, %s)\n", access(acs), idx)
+	fmt.Fprintf(b, "\treturn %s(v), err\n}\n", typ)
+}
+
+// genWrite generates write method for a data functionality.
+func genWrite(b *strings.Builder, blkName string, f fn.Func, width int64, acs types.Access) {
+	method := "Write" + exported(f.Name)
+	typ := goType(width)
+
+	b.WriteString("\n")
+	docComment(b, method, f.Doc, "")
+	if f.IsArray {
+		fmt.Fprintf(b, "func (blk *%s) %s(idx int, v %s) error {\n", blkName, method, typ)
+	} else {
+		fmt.Fprintf(b, "func (blk *%s) %s(v %s) error {\n", blkName, method, typ)
+	}
+
+	idx := "0"
+	if f.IsArray {
+		idx = "idx"
+	}
+
+	if width > 64 {
+		fmt.Fprintf(b, "\treturn writeBig(blk.iface, blk.base, %s, %s, v)\n}\n", access(acs), idx)
+		return
+	}
+	fmt.Fprintf(b, "\treturn writeUint(blk.iface, blk.base, %s, %s, uint64(v))\n}\n", access(acs), idx)
+}
+
+// genEnum generates constants for named values of the functionality.
+// Constant names are the prefix followed by the value name.
+func genEnum(b *strings.Builder, prefix string, enum types.Enum) {
+	if len(enum) == 0 {
+		return
+	}
+	b.WriteString("\nconst (\n")
+	for _, ev := range enum {
+		fmt.Fprintf(b, "\t%s%s = %d\n", prefix, exported(ev.Name), ev.Value)
+	}
+	b.WriteString(")\n")
+}
+
+// genFields generates functions getting and setting bit fields of the item value.
+// The item must be read or written as a whole, so the access stays atomic.
+// Functions are not generated for items wider than 64 bits.
+func genFields(b *strings.Builder, prefix string, width int64, fields []*fn.Field) {
+	if width > 64 {
+		return
+	}
+	typ := goType(width)
+	for _, f := range fields {
+		ftyp := goType(f.Width)
+		mask := fmt.Sprintf("0x%X", uint64(1)<<f.Width-1)
+		fmt.Fprintf(b, "\n// %s%s returns the %s field of the value.\n", prefix, exported(f.Name), f.Name)
+		fmt.Fprintf(
+			b, "func %s%s(v %s) %s {\n\treturn %s((v >> %d) & %s)\n}\n",
+			prefix, exported(f.Name), typ, ftyp, ftyp, f.Offset, mask,
+		)
+		fmt.Fprintf(b, "\n// Set%s%s returns the value with the %s field replaced.\n", prefix, exported(f.Name), f.Name)
+		fmt.Fprintf(
+			b, "func Set%[1]s%[2]s(v %[3]s, f %[4]s) %[3]s {\n\treturn v&^(%[5]s<<%[6]d) | (%[3]s(f)&%[5]s)<<%[6]d\n}\n",
+			prefix, exported(f.Name), typ, ftyp, mask, f.Offset,
+		)
+	}
+}
+
+func genIrq(b *strings.Builder, blkName string, irq *fn.Irq) {
+	genRead(b, blkName, irq.Func, 1, irq.Access)
+
+	if irq.ClearAddr != nil {
+		method := "Clear" + exported(irq.Name)
+		fmt.Fprintf(b, "\n// %s explicitly clears the %s irq.\n", method, irq.Name)
+		if irq.IsArray {
+			fmt.Fprintf(b, "func (blk *%s) %s(idx int) error {\n", blkName, method)
+		} else {
+			fmt.Fprintf(b, "func (blk *%s) %s() error {\n", blkName, method)
+			b.WriteString("\tidx := 0\n")
+		}
+		fmt.Fprintf(b, "\tchunks, err := %s.chunks(idx)\n", access(irq.Access))
+		b.WriteString("\tif err != nil {\n\t\treturn err\n\t}\n")
+		// Array items are cleared at the same register offset as their flags.
+		addr := "blk.base+chunks[0].addr"
+		if offset := *irq.ClearAddr - irq.Access.StartAddr; offset != 0 {
+			addr = fmt.Sprintf("%s%+d", addr, offset)
+		}
+		fmt.Fprintf(b, "\treturn blk.iface.Write(%s, uint64(1)<<chunks[0].bit)\n}\n", addr)
+	}
+
+	if irq.AddEnable {
+		enable := irq.Func
+		enable.Name = irq.Name + "_enable"
+		enable.Doc = ""
+		genRead(b, blkName, enable, 1, irq.EnableAccess)
+		genWrite(b, blkName, enable, 1, irq.EnableAccess)
+	}
+}
+
+func paramsDecl(params []*fn.Param) string {
+	decls := []string{}
+	for _, p := range params {
+		decls = append(decls, fmt.Sprintf("%s %s", local(p.Name), valType(p.Func, p.Width)))
+	}
+	return strings.Join(decls, ", ")
+}
+
+func argsList(params []*fn.Param) string {
+	args := []string{}
+	for _, p := range params {
+		var vals string
+		if p.Width > 64 {
+			if p.IsArray {
+				vals = local(p.Name) + "[:]"
+			} else {
+				vals = fmt.Sprintf("[]*big.Int{%s}", local(p.Name))
+			}
+		} else {
+			if p.IsArray {
+				vals = fmt.Sprintf("toBigs(%s[:])", local(p.Name))
+			} else {
+				vals = fmt.Sprintf("toBigs([]%s{%s})", goType(p.Width), local(p.Name))
+			}
+		}
+		args = append(args, fmt.Sprintf("{%s, %s}", access(p.Access), vals))
+	}
+	return "[]arg{" + strings.Join(args, ", ") + "}"
+}
+
+func retsList(returns []*fn.Return) string {
+	rets := []string{}
+	for _, r := range returns {
+		rets = append(rets, strings.TrimPrefix(access(r.Access), "access"))
+	}
+	return "[]access{" + strings.Join(rets, ", ") + "}"
+}
+
+func retsDecl(returns []*fn.Return) string {
+	types := []string{}
+	for _, r := range returns {
+		types = append(types, valType(r.Func, r.Width))
+	}
+	types = append(types, "error")
+	if len(types) == 1 {
+		return "error"
+	}
+	return "(" + strings.Join(types, ", ") + ")"
+}
+
+// genReturns generates code converting read values into returns.
+func genReturns(b *strings.Builder, returns []*fn.Return) {
+	b.WriteString("\tif err != nil {\n\t\treturn ")
+	for _, r := range returns {
+		b.WriteString(zero(r.Func, r.Width) + ", ")
+	}
+	b.WriteString("err\n\t}\n")
+
+	rets := []string{}
+	for i, r := range returns {
+		if r.IsArray {
+			fmt.Fprintf(b, "\tvar r%d %s\n", i, valType(r.Func, r.Width))
+			if r.Width > 64 {
+				fmt.Fprintf(b, "\tcopy(r%d[:], vals[%d])\n", i, i)
+			} else {
+				fmt.Fprintf(b, "\tfromBigs(vals[%d], r%d[:])\n", i, i)
+			}
+			rets = append(rets, fmt.Sprintf("r%d", i))
+		} else if r.Width > 64 {
+			rets = append(rets, fmt.Sprintf("vals[%d][0]", i))
+		} else {
+			rets = append(rets, fmt.Sprintf("%s(vals[%d][0].Uint64())", goType(r.Width), i))
+		}
+	}
+	rets = append(rets, "nil")
+	fmt.Fprintf(b, "\treturn %s\n", strings.Join(rets, ", "))
+}
+
+func genProc(b *strings.Builder, blkName string, p *fn.Proc) {
+	method := exported(p.Name)
+
+	b.WriteString("\n")
+	docComment(b, method, p.Doc, "")
+	fmt.Fprintf(b, "func (blk *%s) %s(%s) %s {\n", blkName, method, paramsDecl(p.Params), retsDecl(p.Returns))
+
+	vals := "vals"
+	if len(p.Returns) == 0 {
+		vals = "_"
+	}
+	fmt.Fprintf(
+		b, "\t%s, err := call(\n\t\tblk.iface, blk.base,\n\t\t%s,\n\t\t%s,\n\t\t%s, %s, %s,\n\t)\n",
+		vals, argsList(p.Params), retsList(p.Returns), optAddr(p.CallAddr), optAddr(p.ExitAddr), delay(p.Delay),
+	)
+
+	if len(p.Returns) == 0 {
+		b.WriteString("\treturn err\n}\n")
+		return
+	}
+	genReturns(b, p.Returns)
+	b.WriteString("}\n")
+}
+
+// genParamEnums generates constants for named values of the proc or stream params.
+func genParamEnums(b *strings.Builder, prefix string, params []*fn.Param) {
+	for _, p := range params {
+		genEnum(b, prefix+exported(p.Name), p.Values)
+	}
+}
+
+// genStream generates stream method.
+// The stream strobe is generated by the access to the StbAddr,
+// which is always the last accessed register.
+func genStream(b *strings.Builder, blkName string, s *fn.Stream) {
+	method := exported(s.Name)
+
+	b.WriteString("\n")
+	docComment(b, method, s.Doc, "")
+	fmt.Fprintf(b, "func (blk *%s) %s(%s) %s {\n", blkName, method, paramsDecl(s.Params), retsDecl(s.Returns))
+
+	if s.IsDownstream() {
+		fmt.Fprintf(b, "\treturn writeBuffer(blk.iface, blk.base, %s, %d)\n}\n", argsList(s.Params), s.StbAddr)
+		return
+	}
+
+	fmt.Fprintf(b, "\tvals, err := readBuffer(blk.iface, blk.base, %s, %d)\n", retsList(s.Returns), s.StbAddr)
+	genReturns(b, s.Returns)
+	b.WriteString("}\n")
+}
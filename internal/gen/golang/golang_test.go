@@ -0,0 +1,27 @@
+package golang
+
+import (
+	"testing"
+)
+
+func TestGoType(t *testing.T) {
+	var tests = []struct {
+		width int64
+		want  string
+	}{
+		{1, "uint8"},
+		{8, "uint8"},
+		{9, "uint16"},
+		{32, "uint32"},
+		{33, "uint64"},
+		{64, "uint64"},
+		{65, "*big.Int"},
+	}
+
+	for i, test := range tests {
+		got := goType(test.width)
+		if got != test.want {
+			t.Errorf("[%d]: got %q, want %q", i, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package python
+
+// accessPy is the content of the _access.py module.
+// The module implements generic packing and unpacking of functionalities
+// values, so that the generated classes only have to describe accesses.
+const accessPy = `"""Register access helpers used by the generated classes.
+
+This file has been generated by fbdl. Do not edit.
+"""
+
+import time
+
+
+class Access:
+    """Access describes placement of a functionality within registers.
+
+    Items is a tuple with chunks of each item. Each chunk is an (addr, bit, width, shift) tuple.
+    The item bits [shift + width - 1:shift] are placed in the register
+    under address addr at bits [bit + width - 1:bit].
+    Addresses are relative to the start address of the enclosing block.
+    """
+
+    __slots__ = ("is_array", "reg_width", "item_width", "items")
+
+    def __init__(self, is_array, reg_width, item_width, items):
+        self.is_array = is_array
+        self.reg_width = reg_width
+        self.item_width = item_width
+        self.items = items
+
+    @property
+    def item_count(self):
+        return len(self.items)
+
+    @property
+    def start_addr(self):
+        return self.items[0][0][0]
+
+    def chunks(self, idx=0):
+        """Return chunks of the item with given index."""
+        if not 0 <= idx < len(self.items):
+            raise IndexError("index {} out of range [0:{}]".format(idx, len(self.items) - 1))
+        return self.items[idx]
+
+
+def mask(width):
+    return (1 << width) - 1
+
+
+def check_value(value, width):
+    if not 0 <= value <= mask(width):
+        raise ValueError("value {} out of range [0:{}]".format(value, mask(width)))
+
+
+def read_chunks(iface, base, chunks):
+    """Read value placed in the registers as described by the chunks."""
+    value = 0
+    for addr, bit, width, shift in chunks:
+        data = iface.read(base + addr)
+        value |= ((data >> bit) & mask(width)) << shift
+    return value
+
+
+def write_chunks(iface, base, chunks, reg_width, value):
+    """Write value placed in the registers as described by the chunks.
+
+    Registers only partially occupied by the value are read-modify-written.
+    """
+    for addr, bit, width, shift in chunks:
+        part = (value >> shift) & mask(width)
+        if width == reg_width:
+            data = part
+        else:
+            data = iface.read(base + addr) & ~(mask(width) << bit) & mask(reg_width)
+            data |= part << bit
+        iface.write(base + addr, data)
+
+
+def read(iface, base, acs, idx=0):
+    """Read value of the item with given index."""
+    return read_chunks(iface, base, acs.chunks(idx))
+
+
+def write(iface, base, acs, value, idx=0):
+    """Write value of the item with given index."""
+    check_value(value, acs.item_width)
+    write_chunks(iface, base, acs.chunks(idx), acs.reg_width, value)
+
+
+class Array:
+    """Array provides indexed access to an array functionality."""
+
+    def __init__(self, iface, base, acs, writable, readable=True):
+        self._iface = iface
+        self._base = base
+        self._acs = acs
+        self._writable = writable
+        self._readable = readable
+
+    def __len__(self):
+        return self._acs.item_count
+
+    def __getitem__(self, idx):
+        if not self._readable:
+            raise AttributeError("array is write-only")
+        if isinstance(idx, slice):
+            return [self[i] for i in range(*idx.indices(len(self)))]
+        return read(self._iface, self._base, self._acs, idx)
+
+    def __setitem__(self, idx, value):
+        if not self._writable:
+            raise AttributeError("array is read-only")
+        if isinstance(idx, slice):
+            for i, v in zip(range(*idx.indices(len(self))), value):
+                write(self._iface, self._base, self._acs, v, i)
+            return
+        write(self._iface, self._base, self._acs, value, idx)
+
+    def read(self):
+        """Read all items."""
+        return [self[i] for i in range(len(self))]
+
+    def write(self, values):
+        """Write all items."""
+        if len(values) != len(self):
+            raise ValueError("invalid number of values {}, expected {}".format(len(values), len(self)))
+        for i, v in enumerate(values):
+            self[i] = v
+
+
+class Irq:
+    """Irq provides access to an irq flag, its enable and explicit clear."""
+
+    def __init__(self, iface, base, acs, enable_acs, clear_addr):
+        self._iface = iface
+        self._base = base
+        self._acs = acs
+        self._enable_acs = enable_acs
+        self._clear_addr = clear_addr
+
+    def read(self, idx=0):
+        """Read irq flag."""
+        return read(self._iface, self._base, self._acs, idx)
+
+    def clear(self, idx=0):
+        """Explicitly clear irq flag."""
+        if self._clear_addr is None:
+            raise AttributeError("irq is cleared on read")
+        addr, bit, _, _ = self._acs.chunks(idx)[0]
+        self._iface.write(self._base + self._clear_addr + addr - self._acs.start_addr, 1 << bit)
+
+    def read_enable(self, idx=0):
+        """Read irq enable."""
+        if self._enable_acs is None:
+            raise AttributeError("irq has no enable")
+        return read(self._iface, self._base, self._enable_acs, idx)
+
+    def write_enable(self, value, idx=0):
+        """Write irq enable."""
+        if self._enable_acs is None:
+            raise AttributeError("irq has no enable")
+        write(self._iface, self._base, self._enable_acs, value, idx)
+
+
+def _values(acs, value):
+    if acs.is_array:
+        if len(value) != acs.item_count:
+            raise ValueError("invalid number of values {}, expected {}".format(len(value), acs.item_count))
+        return list(enumerate(value))
+    return [(0, value)]
+
+
+def write_buffer(iface, base, params, strobe_addr):
+    """Write params buffer.
+
+    Params is a list of (access, value) tuples.
+    Registers are written in increasing address order, so the write
+    to the strobe address (call address) is always the last one.
+    """
+    regs = {}
+    for acs, value in params:
+        for idx, v in _values(acs, value):
+            check_value(v, acs.item_width)
+            for addr, bit, width, shift in acs.chunks(idx):
+                regs[addr] = regs.get(addr, 0) | (((v >> shift) & mask(width)) << bit)
+
+    if strobe_addr is not None:
+        regs.setdefault(strobe_addr, 0)
+
+    for addr in sorted(regs):
+        iface.write(base + addr, regs[addr])
+
+
+def read_buffer(iface, base, returns, strobe_addr):
+    """Read returns buffer.
+
+    Returns is a list of accesses.
+    Registers are read in increasing address order, so the read
+    from the strobe address (exit address) is always the last one.
+    """
+    addrs = set()
+    for acs in returns:
+        for chunks in acs.items:
+            addrs.update(addr for addr, _, _, _ in chunks)
+    if strobe_addr is not None:
+        addrs.add(strobe_addr)
+
+    regs = {}
+    for addr in sorted(addrs):
+        regs[addr] = iface.read(base + addr)
+
+    values = []
+    for acs in returns:
+        items = []
+        for chunks in acs.items:
+            value = 0
+            for addr, bit, width, shift in chunks:
+                value |= ((regs[addr] >> bit) & mask(width)) << shift
+            items.append(value)
+        if acs.is_array:
+            values.append(items)
+        else:
+            values.append(items[0])
+
+    if len(values) == 0:
+        return None
+    elif len(values) == 1:
+        return values[0]
+    return tuple(values)
+
+
+def call(iface, base, params, returns, call_addr, exit_addr, delay):
+    """Call proc.
+
+    Params is a list of (access, value) tuples, returns is a list of accesses.
+    Delay is the proc delay in seconds, or None if proc has no delay.
+    """
+    if params or call_addr is not None:
+        write_buffer(iface, base, params, call_addr)
+
+    if delay is not None:
+        time.sleep(delay)
+
+    if returns or exit_addr is not None:
+        return read_buffer(iface, base, returns, exit_addr)
+
+    return None
+`
@@ -0,0 +1,466 @@
+// Package python implements Python register access package generator.
+//
+// The generated package contains one class per block.
+// Classes are parameterized by the user-supplied transport object,
+// which must implement read(addr) and write(addr, data) methods.
+package python
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+const indent = "    "
+
+var keywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true, "finally": true,
+	"for": true, "from": true, "global": true, "if": true, "import": true, "in": true,
+	"is": true, "lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true,
+	"raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// Generate generates Python package for the bus and writes it into the directory under path.
+func Generate(bus *fn.Block, path string) error {
+	err := os.MkdirAll(path, 0755)
+	if err != nil {
+		return fmt.Errorf("generate python: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(path, "_access.py"), []byte(accessPy), 0644)
+	if err != nil {
+		return fmt.Errorf("generate python: %v", err)
+	}
+
+	b := strings.Builder{}
+	b.WriteString(`"""Register access package for the '` + bus.Name + `' bus.
+
+This file has been generated by fbdl. Do not edit.
+"""
+
+from ._access import Access, Array, Irq
+from . import _access
+`)
+	genBlock(&b, bus, []string{bus.Name}, bus.AddrSpace.Start)
+
+	err = os.WriteFile(filepath.Join(path, "__init__.py"), []byte(b.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("generate python: %v", err)
+	}
+
+	return nil
+}
+
+// className returns class name for the block with given path.
+func className(path []string) string {
+	b := strings.Builder{}
+	for _, p := range path {
+		for _, w := range strings.Split(p, "_") {
+			if w == "" {
+				continue
+			}
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return b.String()
+}
+
+// subPath returns path of the subblock with given name.
+func subPath(path []string, name string) []string {
+	sp := make([]string, len(path), len(path)+1)
+	copy(sp, path)
+	return append(sp, name)
+}
+
+// ident returns valid Python identifier for the functionality name.
+func ident(name string) string {
+	if keywords[name] {
+		return name + "_"
+	}
+	return name
+}
+
+func docString(b *strings.Builder, doc string, ind string) {
+	if doc == "" {
+		return
+	}
+	doc = strings.ReplaceAll(doc, `"""`, `\"\"\"`)
+	lines := strings.Split(doc, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s\"\"\"%s\"\"\"\n", ind, doc)
+		return
+	}
+	fmt.Fprintf(b, "%s\"\"\"%s\n", ind, lines[0])
+	for _, l := range lines[1:] {
+		if l == "" {
+			b.WriteString("\n")
+		} else {
+			fmt.Fprintf(b, "%s%s\n", ind, l)
+		}
+	}
+	fmt.Fprintf(b, "%s\"\"\"\n", ind)
+}
+
+// chunks returns Python tuple of (addr, bit, width, shift) chunk tuples.
+func chunks(chs []types.Chunk) string {
+	items := []string{}
+	for _, c := range chs {
+		items = append(items, fmt.Sprintf("(%d, %d, %d, %d)", c.Addr, c.Bit, c.Width, c.Shift))
+	}
+	if len(items) == 1 {
+		return "(" + items[0] + ",)"
+	}
+	return "(" + strings.Join(items, ", ") + ")"
+}
+
+// access returns Access object with chunks of all items.
+// Chunks are generated from the access, so the generated code does not have to know access types.
+func access(acs types.Access) string {
+	items := make([]string, acs.ItemCount)
+	for i := range acs.ItemCount {
+		items[i] = chunks(acs.Chunks(i))
+	}
+	return fmt.Sprintf(
+		"Access(%s, %d, %d, (%s,))",
+		map[bool]string{true: "True", false: "False"}[strings.HasPrefix(acs.Type, "Array")],
+		acs.RegWidth, acs.ItemWidth, strings.Join(items, ", "),
+	)
+}
+
+func optAddr(addr *int64) string {
+	if addr == nil {
+		return "None"
+	}
+	return fmt.Sprintf("%d", *addr)
+}
+
+func delay(d *types.Time) string {
+	if d == nil {
+		return "None"
+	}
+	return fmt.Sprintf("%d.%09d", d.S, d.Ns)
+}
+
+func genBlock(b *strings.Builder, blk *fn.Block, path []string, base int64) {
+	// Subblocks classes must be defined before the class using them.
+	for _, sb := range blk.Subblocks {
+		genBlock(b, sb, subPath(path, sb.Name), sb.AddrSpace.Start)
+	}
+
+	fmt.Fprintf(b, "\n\nclass %s:\n", className(path))
+	docString(b, blk.Doc, indent)
+	if blk.Doc != "" {
+		b.WriteString("\n")
+	}
+
+	// Class attributes with accesses.
+	for _, c := range blk.Configs {
+		fmt.Fprintf(b, "%s_%s_acs = %s\n", indent, c.Name, access(c.Access))
+	}
+	for _, i := range blk.Irqs {
+		fmt.Fprintf(b, "%s_%s_acs = %s\n", indent, i.Name, access(i.Access))
+		if i.AddEnable {
+			fmt.Fprintf(b, "%s_%s_enable_acs = %s\n", indent, i.Name, access(i.EnableAccess))
+		}
+	}
+	for _, m := range blk.Masks {
+		fmt.Fprintf(b, "%s_%s_acs = %s\n", indent, m.Name, access(m.Access))
+	}
+	for _, s := range blk.Statics {
+		fmt.Fprintf(b, "%s_%s_acs = %s\n", indent, s.Name, access(s.Access))
+	}
+	for _, s := range blk.Statuses {
+		fmt.Fprintf(b, "%s_%s_acs = %s\n", indent, s.Name, access(s.Access))
+	}
+
+	// Class attributes with field chunks.
+	for _, c := range blk.Configs {
+		genFieldChunks(b, c.Func, c.Access, c.Fields, c.Atomic)
+	}
+	for _, s := range blk.Statics {
+		genFieldChunks(b, s.Func, s.Access, s.Fields, false)
+	}
+	for _, s := range blk.Statuses {
+		genFieldChunks(b, s.Func, s.Access, s.Fields, s.Atomic)
+	}
+
+	// Class attributes with named values.
+	for _, c := range blk.Configs {
+		genEnum(b, c.Name, c.Values)
+	}
+	for _, s := range blk.Statuses {
+		genEnum(b, s.Name, s.Values)
+	}
+	for _, p := range blk.Procs {
+		for _, pp := range p.Params {
+			genEnum(b, p.Name+"_"+pp.Name, pp.Values)
+		}
+	}
+	for _, s := range blk.Streams {
+		for _, p := range s.Params {
+			genEnum(b, s.Name+"_"+p.Name, p.Values)
+		}
+	}
+
+	fmt.Fprintf(b, "\n%sdef __init__(self, iface, base=%d):\n", indent, base)
+	fmt.Fprintf(b, "%[1]s%[1]sself._iface = iface\n", indent)
+	fmt.Fprintf(b, "%[1]s%[1]sself._base = base\n", indent)
+	for _, sb := range blk.Subblocks {
+		offset := sb.AddrSpace.Start - blk.AddrSpace.Start
+		cls := className(subPath(path, sb.Name))
+		if sb.IsArray {
+			fmt.Fprintf(
+				b, "%[1]s%[1]sself.%[2]s = [%[3]s(iface, base + %[4]d + i * %[5]d) for i in range(%[6]d)]\n",
+				indent, ident(sb.Name), cls, offset, sb.Sizes.Aligned, sb.Count,
+			)
+		} else {
+			fmt.Fprintf(b, "%[1]s%[1]sself.%[2]s = %[3]s(iface, base + %[4]d)\n", indent, ident(sb.Name), cls, offset)
+		}
+	}
+	for _, i := range blk.Irqs {
+		enable := "None"
+		if i.AddEnable {
+			enable = fmt.Sprintf("self._%s_enable_acs", i.Name)
+		}
+		fmt.Fprintf(
+			b, "%[1]s%[1]sself.%[2]s = Irq(iface, base, self._%[3]s_acs, %[4]s, %[5]s)\n",
+			indent, ident(i.Name), i.Name, enable, optAddr(i.ClearAddr),
+		)
+	}
+
+	for _, c := range blk.Configs {
+		genData(b, c.Func, c.IsReadable(), c.IsWritable())
+		genFields(b, c.Func, c.Access, c.Fields, c.Atomic, c.IsReadable(), c.IsWritable())
+	}
+	for _, m := range blk.Masks {
+		genData(b, m.Func, m.IsReadable(), m.IsWritable())
+	}
+	for _, s := range blk.Statics {
+		genData(b, s.Func, true, false)
+		genFields(b, s.Func, s.Access, s.Fields, false, true, false)
+	}
+	for _, s := range blk.Statuses {
+		genData(b, s.Func, true, false)
+		genFields(b, s.Func, s.Access, s.Fields, s.Atomic, true, false)
+	}
+	for _, p := range blk.Procs {
+		genProc(b, p)
+	}
+	for _, s := range blk.Streams {
+		genStream(b, s)
+	}
+}
+
+// genEnum generates class attributes for named values of the functionality,
+// for example "MODE_IDLE = 0".
+func genEnum(b *strings.Builder, name string, enum types.Enum) {
+	for _, ev := range enum {
+		fmt.Fprintf(b, "%s%s_%s = %d\n", indent, strings.ToUpper(name), strings.ToUpper(ev.Name), ev.Value)
+	}
+}
+
+// genData generates property for a data functionality (config, mask, static or status).
+func genData(b *strings.Builder, f fn.Func, readable, writable bool) {
+	name := ident(f.Name)
+	ind := indent + indent
+	pyBool := map[bool]string{true: "True", false: "False"}
+
+	if f.IsArray {
+		fmt.Fprintf(b, "\n%s@property\n", indent)
+		fmt.Fprintf(b, "%sdef %s(self):\n", indent, name)
+		docString(b, f.Doc, ind)
+		fmt.Fprintf(
+			b, "%sreturn Array(self._iface, self._base, self._%s_acs, %s, %s)\n",
+			ind, f.Name, pyBool[writable], pyBool[readable],
+		)
+		if writable {
+			fmt.Fprintf(b, "\n%s@%s.setter\n", indent, name)
+			fmt.Fprintf(b, "%sdef %s(self, values):\n", indent, name)
+			fmt.Fprintf(b, "%sself.%s.write(values)\n", ind, name)
+		}
+		return
+	}
+
+	// Write only functionalities have a property without getter,
+	// as reading them returns undefined data.
+	if !readable {
+		fmt.Fprintf(b, "\n%sdef _set_%s(self, value):\n", indent, f.Name)
+		docString(b, f.Doc, ind)
+		fmt.Fprintf(b, "%s_access.write(self._iface, self._base, self._%s_acs, value)\n", ind, f.Name)
+		fmt.Fprintf(b, "\n%s%s = property(None, _set_%s)\n", indent, name, f.Name)
+		return
+	}
+
+	fmt.Fprintf(b, "\n%s@property\n", indent)
+	fmt.Fprintf(b, "%sdef %s(self):\n", indent, name)
+	docString(b, f.Doc, ind)
+	fmt.Fprintf(b, "%sreturn _access.read(self._iface, self._base, self._%s_acs)\n", ind, f.Name)
+	if writable {
+		fmt.Fprintf(b, "\n%s@%s.setter\n", indent, name)
+		fmt.Fprintf(b, "%sdef %s(self, value):\n", indent, name)
+		fmt.Fprintf(b, "%s_access.write(self._iface, self._base, self._%s_acs, value)\n", ind, f.Name)
+	}
+}
+
+// fieldsInChunks returns true if fields of the items can be accessed using their chunks.
+// Atomic items spanning multiple registers must always be accessed as a whole.
+func fieldsInChunks(acs types.Access, atomic bool) bool {
+	if !atomic {
+		return true
+	}
+	for i := range acs.ItemCount {
+		if len(acs.Chunks(i)) > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// genFieldChunks generates class attributes with chunks of the functionality fields.
+// For arrays, the attribute is a tuple with chunks of each item.
+func genFieldChunks(b *strings.Builder, f fn.Func, acs types.Access, fields []*fn.Field, atomic bool) {
+	if !fieldsInChunks(acs, atomic) {
+		return
+	}
+	for _, fld := range fields {
+		fmt.Fprintf(b, "%s_%s_%s_chunks = ", indent, f.Name, fld.Name)
+		if !f.IsArray {
+			fmt.Fprintf(b, "%s\n", chunks(fld.Chunks(acs, 0)))
+			continue
+		}
+		items := []string{}
+		for i := range acs.ItemCount {
+			items = append(items, chunks(fld.Chunks(acs, i)))
+		}
+		fmt.Fprintf(b, "(%s,)\n", strings.Join(items, ", "))
+	}
+}
+
+// genFields generates get and set methods for bit fields of a data functionality.
+// Only registers holding the field are accessed, unless fields cannot be accessed using their chunks.
+// In such a case, the whole item is read, and for the set method also written.
+func genFields(
+	b *strings.Builder, f fn.Func, acs types.Access, fields []*fn.Field, atomic, readable, writable bool,
+) {
+	ind := indent + indent
+	inChunks := fieldsInChunks(acs, atomic)
+	if !inChunks && !readable {
+		return
+	}
+
+	params, idx, chunksIdx := "self", "", ""
+	if f.IsArray {
+		params, idx, chunksIdx = "self, idx", ", idx", "[idx]"
+	}
+
+	for _, fld := range fields {
+		name := f.Name + "_" + fld.Name
+		mask := fmt.Sprintf("0x%X", new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(fld.Width)), big.NewInt(1)))
+
+		if readable {
+			fmt.Fprintf(b, "\n%sdef get_%s(%s):\n", indent, name, params)
+			fmt.Fprintf(b, "%s\"\"\"Read the %s field of %s.\"\"\"\n", ind, fld.Name, f.Name)
+			if inChunks {
+				fmt.Fprintf(
+					b, "%sreturn _access.read_chunks(self._iface, self._base, self._%s_chunks%s)\n",
+					ind, name, chunksIdx,
+				)
+			} else {
+				fmt.Fprintf(
+					b, "%sreturn (_access.read(self._iface, self._base, self._%s_acs%s) >> %d) & %s\n",
+					ind, f.Name, idx, fld.Offset, mask,
+				)
+			}
+		}
+
+		if !writable {
+			continue
+		}
+		fmt.Fprintf(b, "\n%sdef set_%s(%s, value):\n", indent, name, params)
+		fmt.Fprintf(b, "%s\"\"\"Write the %s field of %s.\"\"\"\n", ind, fld.Name, f.Name)
+		fmt.Fprintf(b, "%s_access.check_value(value, %d)\n", ind, fld.Width)
+		if inChunks {
+			fmt.Fprintf(
+				b, "%s_access.write_chunks(self._iface, self._base, self._%s_chunks%s, %d, value)\n",
+				ind, name, chunksIdx, acs.RegWidth,
+			)
+		} else {
+			fmt.Fprintf(
+				b, "%sv = _access.read(self._iface, self._base, self._%s_acs%s) & ~(%s << %d)\n",
+				ind, f.Name, idx, mask, fld.Offset,
+			)
+			fmt.Fprintf(
+				b, "%s_access.write(self._iface, self._base, self._%s_acs, v | value << %d%s)\n",
+				ind, f.Name, fld.Offset, idx,
+			)
+		}
+	}
+}
+
+func paramNames(params []*fn.Param) string {
+	names := []string{"self"}
+	for _, p := range params {
+		names = append(names, ident(p.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+func paramsList(params []*fn.Param) string {
+	items := []string{}
+	for _, p := range params {
+		items = append(items, fmt.Sprintf("(%s, %s)", access(p.Access), ident(p.Name)))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+func returnsList(returns []*fn.Return) string {
+	items := []string{}
+	for _, r := range returns {
+		items = append(items, access(r.Access))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+func genProc(b *strings.Builder, p *fn.Proc) {
+	ind := indent + indent
+
+	fmt.Fprintf(b, "\n%sdef %s(%s):\n", indent, ident(p.Name), paramNames(p.Params))
+	docString(b, p.Doc, ind)
+	fmt.Fprintf(
+		b, "%sreturn _access.call(\n%s%sself._iface, self._base,\n", ind, ind, indent,
+	)
+	fmt.Fprintf(b, "%s%s%s,\n", ind, indent, paramsList(p.Params))
+	fmt.Fprintf(b, "%s%s%s,\n", ind, indent, returnsList(p.Returns))
+	fmt.Fprintf(
+		b, "%s%s%s, %s, %s,\n%s)\n",
+		ind, indent, optAddr(p.CallAddr), optAddr(p.ExitAddr), delay(p.Delay), ind,
+	)
+}
+
+// genStream generates stream method.
+// The stream strobe is generated by the access to the StbAddr,
+// which is always the last accessed register.
+func genStream(b *strings.Builder, s *fn.Stream) {
+	ind := indent + indent
+
+	fmt.Fprintf(b, "\n%sdef %s(%s):\n", indent, ident(s.Name), paramNames(s.Params))
+	docString(b, s.Doc, ind)
+	if s.IsDownstream() {
+		fmt.Fprintf(
+			b, "%s_access.write_buffer(self._iface, self._base, %s, %d)\n",
+			ind, paramsList(s.Params), s.StbAddr,
+		)
+	} else {
+		fmt.Fprintf(
+			b, "%sreturn _access.read_buffer(self._iface, self._base, %s, %d)\n",
+			ind, returnsList(s.Returns), s.StbAddr,
+		)
+	}
+}
@@ -0,0 +1,45 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+func TestClassName(t *testing.T) {
+	var tests = []struct {
+		path []string
+		want string
+	}{
+		{[]string{"main"}, "Main"},
+		{[]string{"main", "sub"}, "MainSub"},
+		{[]string{"main", "dma_ch", "regs"}, "MainDmaChRegs"},
+	}
+
+	for i, test := range tests {
+		got := className(test.path)
+		if got != test.want {
+			t.Errorf("[%d]: got %q, want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestChunks(t *testing.T) {
+	var tests = []struct {
+		chunks []types.Chunk
+		want   string
+	}{
+		{[]types.Chunk{{Addr: 2, Bit: 12, Width: 1, Shift: 0}}, "((2, 12, 1, 0),)"},
+		{
+			[]types.Chunk{{Addr: 3, Bit: 20, Width: 12, Shift: 0}, {Addr: 4, Bit: 0, Width: 8, Shift: 12}},
+			"((3, 20, 12, 0), (4, 0, 8, 12))",
+		},
+	}
+
+	for i, test := range tests {
+		got := chunks(test.chunks)
+		if got != test.want {
+			t.Errorf("[%d]: got %q, want %q", i, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package rust
+
+// accessRs is the content of the access.rs module.
+// The module implements generic packing and unpacking of functionalities
+// values, so that the generated methods only have to describe accesses.
+const accessRs = `//! Register access helpers used by the generated blocks.
+//!
+//! This file has been generated by fbdl. Do not edit.
+
+use crate::{Reg, BUS_WIDTH};
+
+/// Chunk describes part of an item placed within single register.
+/// The item bits [shift + width - 1:shift] are placed in the register
+/// under address addr at bits [bit + width - 1:bit].
+#[derive(Clone, Copy, Debug)]
+pub struct Chunk {
+    pub addr: usize,
+    pub bit: u32,
+    pub width: u32,
+    pub shift: u32,
+}
+
+impl Chunk {
+    pub const fn new(addr: usize, bit: u32, width: u32, shift: u32) -> Self {
+        Chunk { addr, bit, width, shift }
+    }
+}
+
+/// Access describes placement of a functionality within registers.
+/// Items holds chunks of each item.
+/// Addresses are relative to the start address of the enclosing block.
+#[derive(Clone, Copy, Debug)]
+pub struct Access {
+    pub item_width: u32,
+    pub items: &'static [&'static [Chunk]],
+}
+
+const fn mask(width: u32) -> u128 {
+    if width >= 128 {
+        !0
+    } else {
+        (1 << width) - 1
+    }
+}
+
+impl Access {
+    /// Returns chunks of the item with given index.
+    ///
+    /// Panics if the index is out of range.
+    pub fn chunks(&self, idx: usize) -> &'static [Chunk] {
+        assert!(
+            idx < self.items.len(),
+            "index {} out of range [0:{}]",
+            idx,
+            self.items.len() - 1
+        );
+        self.items[idx]
+    }
+
+    /// Calls f for each chunk of the item with given index.
+    ///
+    /// Panics if the index is out of range.
+    pub fn for_each_chunk(&self, idx: usize, mut f: impl FnMut(Chunk)) {
+        for c in self.chunks(idx) {
+            f(*c);
+        }
+    }
+
+    fn check_value(&self, v: u128) {
+        assert!(
+            v <= mask(self.item_width),
+            "value {} out of range [0:{}]",
+            v,
+            mask(self.item_width)
+        );
+    }
+}
+
+/// Reads value of the item with given index.
+///
+/// # Safety
+///
+/// The base must point to the start of the block the access belongs to.
+pub unsafe fn read(base: *mut Reg, acs: &Access, idx: usize) -> u128 {
+    let mut v = 0;
+    acs.for_each_chunk(idx, |c| {
+        let data = core::ptr::read_volatile(base.add(c.addr)) as u128;
+        v |= ((data >> c.bit) & mask(c.width)) << c.shift;
+    });
+    v
+}
+
+/// Writes value of the item with given index.
+/// Registers only partially occupied by the item are read-modify-written.
+///
+/// # Safety
+///
+/// The base must point to the start of the block the access belongs to.
+pub unsafe fn write(base: *mut Reg, acs: &Access, idx: usize, v: u128) {
+    acs.check_value(v);
+    acs.for_each_chunk(idx, |c| {
+        let reg = base.add(c.addr);
+        let mut data = ((v >> c.shift) & mask(c.width)) << c.bit;
+        if c.width != BUS_WIDTH {
+            let old = core::ptr::read_volatile(reg) as u128;
+            data |= old & !(mask(c.width) << c.bit) & mask(BUS_WIDTH);
+        }
+        core::ptr::write_volatile(reg, data as Reg);
+    });
+}
+
+/// Writes 1 to the bit at which the item with given index starts.
+/// The register is at the same offset from addr as the item register from the access start address.
+///
+/// # Safety
+///
+/// The base must point to the start of the block the access belongs to.
+pub unsafe fn strobe(base: *mut Reg, acs: &Access, idx: usize, addr: usize) {
+    let c = acs.chunks(idx)[0];
+    let start_addr = acs.items[0][0].addr;
+    core::ptr::write_volatile(base.add(addr + c.addr - start_addr), (1 as Reg) << c.bit);
+}
+
+fn addr_range(accesses: impl Iterator<Item = Access>, stb_addr: Option<usize>) -> (usize, usize) {
+    let mut start = usize::MAX;
+    let mut end = 0;
+    for acs in accesses {
+        for c in acs.items.iter().flat_map(|chunks| chunks.iter()) {
+            start = core::cmp::min(start, c.addr);
+            end = core::cmp::max(end, c.addr);
+        }
+    }
+    if let Some(addr) = stb_addr {
+        start = core::cmp::min(start, addr);
+        end = core::cmp::max(end, addr);
+    }
+    (start, end)
+}
+
+/// Writes params buffer.
+/// Params is a slice of (access, values) tuples.
+/// Registers are written in increasing address order, so the write
+/// to the strobe address (call address) is always the last one.
+///
+/// # Safety
+///
+/// The base must point to the start of the block the accesses belong to.
+pub unsafe fn write_buffer(base: *mut Reg, params: &[(Access, &[u128])], stb_addr: Option<usize>) {
+    for (acs, vals) in params {
+        for v in vals.iter() {
+            acs.check_value(*v);
+        }
+    }
+
+    let (start, end) = addr_range(params.iter().map(|p| p.0), stb_addr);
+    for addr in start..=end {
+        let mut hit = stb_addr == Some(addr);
+        let mut data = 0;
+        for (acs, vals) in params {
+            for (idx, v) in vals.iter().enumerate() {
+                acs.for_each_chunk(idx, |c| {
+                    if c.addr == addr {
+                        hit = true;
+                        data |= ((v >> c.shift) & mask(c.width)) << c.bit;
+                    }
+                });
+            }
+        }
+        if hit {
+            core::ptr::write_volatile(base.add(addr), data as Reg);
+        }
+    }
+}
+
+/// Reads returns buffer.
+/// Returns is a slice of (access, values) tuples, the values are overwritten.
+/// Registers are read in increasing address order, so the read
+/// from the strobe address (exit address) is always the last one.
+///
+/// # Safety
+///
+/// The base must point to the start of the block the accesses belong to.
+pub unsafe fn read_buffer(base: *mut Reg, returns: &mut [(Access, &mut [u128])], stb_addr: Option<usize>) {
+    for (_, vals) in returns.iter_mut() {
+        vals.fill(0);
+    }
+
+    let (start, end) = addr_range(returns.iter().map(|r| r.0), stb_addr);
+    for addr in start..=end {
+        let mut hit = stb_addr == Some(addr);
+        for (acs, _) in returns.iter() {
+            if acs.items.iter().flat_map(|chunks| chunks.iter()).any(|c| c.addr == addr) {
+                hit = true;
+            }
+        }
+        if !hit {
+            continue;
+        }
+
+        let data = core::ptr::read_volatile(base.add(addr)) as u128;
+        for (acs, vals) in returns.iter_mut() {
+            for (idx, v) in vals.iter_mut().enumerate() {
+                acs.for_each_chunk(idx, |c| {
+                    if c.addr == addr {
+                        *v |= ((data >> c.bit) & mask(c.width)) << c.shift;
+                    }
+                });
+            }
+        }
+    }
+}
+`
@@ -0,0 +1,768 @@
+// Package rust implements Rust no_std peripheral access crate generator.
+//
+// The generated crate contains one module per block.
+// Each block module contains the block struct, which accesses registers
+// via volatile reads and writes of the memory under the block base pointer,
+// and one module per functionality with address and bit-field constants.
+package rust
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+const indent = "    "
+
+// Identifiers that must not be used as names in the generated code.
+var reserved = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "const": true,
+	"continue": true, "crate": true, "dyn": true, "else": true, "enum": true,
+	"extern": true, "false": true, "fn": true, "for": true, "if": true, "impl": true,
+	"in": true, "let": true, "loop": true, "match": true, "mod": true, "move": true,
+	"mut": true, "pub": true, "ref": true, "return": true, "self": true, "static": true,
+	"struct": true, "super": true, "trait": true, "true": true, "type": true,
+	"unsafe": true, "use": true, "where": true, "while": true, "abstract": true,
+	"become": true, "box": true, "do": true, "final": true, "macro": true,
+	"override": true, "priv": true, "try": true, "typeof": true, "unsized": true,
+	"virtual": true, "yield": true,
+	// Identifiers used by the generated code.
+	"access": true, "core": true, "new": true, "wait": true, "idx": true, "v": true,
+}
+
+// Generate generates Rust crate for the bus and writes it into the directory under path.
+// The crate name is the base name of the path.
+func Generate(bus *fn.Block, path string) error {
+	switch bus.Width {
+	case 8, 16, 32, 64:
+	default:
+		return fmt.Errorf(
+			"generate rust: bus width %d is not supported, rust generator supports only 8, 16, 32 and 64 bits buses",
+			bus.Width,
+		)
+	}
+	err := checkWidths(bus)
+	if err != nil {
+		return fmt.Errorf("generate rust: %v", err)
+	}
+
+	crateName := crateName(path)
+
+	err = os.MkdirAll(filepath.Join(path, "src"), 0755)
+	if err != nil {
+		return fmt.Errorf("generate rust: %v", err)
+	}
+
+	cargo := fmt.Sprintf(
+		"# This file has been generated by fbdl. Do not edit.\n\n"+
+			"[package]\nname = %q\nversion = \"0.1.0\"\nedition = \"2021\"\n\n[dependencies]\n",
+		crateName,
+	)
+	err = os.WriteFile(filepath.Join(path, "Cargo.toml"), []byte(cargo), 0644)
+	if err != nil {
+		return fmt.Errorf("generate rust: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(path, "src", "access.rs"), []byte(accessRs), 0644)
+	if err != nil {
+		return fmt.Errorf("generate rust: %v", err)
+	}
+
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "//! Peripheral access crate for the '%s' bus.\n", bus.Name)
+	b.WriteString("//!\n//! This file has been generated by fbdl. Do not edit.\n\n")
+	b.WriteString("#![no_std]\n\npub mod access;\n\n")
+	fmt.Fprintf(&b, "/// Register type, its width equals the bus width.\npub type Reg = u%d;\n\n", bus.Width)
+	fmt.Fprintf(&b, "/// Bus width in bits.\npub const BUS_WIDTH: u32 = %d;\n", bus.Width)
+
+	genBlock(&b, bus, "")
+	fmt.Fprintf(&b, "\npub use %s::%s;\n", snake(bus.Name), structName(bus.Name))
+
+	err = os.WriteFile(filepath.Join(path, "src", "lib.rs"), []byte(b.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("generate rust: %v", err)
+	}
+
+	return nil
+}
+
+// checkWidths checks whether values of all functionalities within the block
+// and its subblocks fit into the Rust primitive types.
+func checkWidths(blk *fn.Block) error {
+	check := func(name string, width int64) error {
+		if width > 128 {
+			return fmt.Errorf(
+				"%s: width %d is greater than 128, rust generator supports only values with width up to 128",
+				name, width,
+			)
+		}
+		return nil
+	}
+
+	var err error
+	for _, c := range blk.Configs {
+		if err = check(c.Name, c.Width); err != nil {
+			return err
+		}
+	}
+	for _, m := range blk.Masks {
+		if err = check(m.Name, m.Width); err != nil {
+			return err
+		}
+	}
+	for _, s := range blk.Statics {
+		if err = check(s.Name, s.Width); err != nil {
+			return err
+		}
+	}
+	for _, s := range blk.Statuses {
+		if err = check(s.Name, s.Width); err != nil {
+			return err
+		}
+	}
+	for _, p := range blk.Procs {
+		for _, pp := range p.Params {
+			if err = check(p.Name+"."+pp.Name, pp.Width); err != nil {
+				return err
+			}
+		}
+		for _, r := range p.Returns {
+			if err = check(p.Name+"."+r.Name, r.Width); err != nil {
+				return err
+			}
+		}
+	}
+	for _, s := range blk.Streams {
+		for _, p := range s.Params {
+			if err = check(s.Name+"."+p.Name, p.Width); err != nil {
+				return err
+			}
+		}
+		for _, r := range s.Returns {
+			if err = check(s.Name+"."+r.Name, r.Width); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, sb := range blk.Subblocks {
+		if err = checkWidths(sb); err != nil {
+			return fmt.Errorf("%s.%v", sb.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func crateName(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	name = strings.Map(func(r rune) rune {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || ('0' <= name[0] && name[0] <= '9') || reserved[name] {
+		name = "fbdl" + name
+	}
+	return name
+}
+
+// ident returns valid Rust identifier for the FBDL name.
+func ident(name string) string {
+	if reserved[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// snake returns snake case Rust identifier for the FBDL name.
+// It is used for module and method names.
+func snake(name string) string {
+	return ident(strings.ToLower(name))
+}
+
+// cast returns cast of u128 value to the given type.
+func cast(typ string) string {
+	if typ == "u128" {
+		return ""
+	}
+	return " as " + typ
+}
+
+// structName returns struct name for the block with given name.
+func structName(name string) string {
+	b := strings.Builder{}
+	for _, w := range strings.Split(name, "_") {
+		if w == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// rustType returns the smallest Rust type capable of holding value of given width.
+func rustType(width int64) string {
+	switch {
+	case width <= 8:
+		return "u8"
+	case width <= 16:
+		return "u16"
+	case width <= 32:
+		return "u32"
+	case width <= 64:
+		return "u64"
+	default:
+		return "u128"
+	}
+}
+
+// valType returns type of the functionality value.
+func valType(f fn.Func, width int64) string {
+	if f.IsArray {
+		return fmt.Sprintf("[%s; %d]", rustType(width), f.Count)
+	}
+	return rustType(width)
+}
+
+// access returns Access value with chunks of all items.
+// Chunks are generated from the access, so the generated code does not have to know access types.
+func access(acs types.Access) string {
+	items := make([]string, acs.ItemCount)
+	for i := range acs.ItemCount {
+		chunks := []string{}
+		for _, c := range acs.Chunks(i) {
+			chunks = append(chunks, fmt.Sprintf("Chunk::new(%d, %d, %d, %d)", c.Addr, c.Bit, c.Width, c.Shift))
+		}
+		items[i] = "&[" + strings.Join(chunks, ", ") + "]"
+	}
+	return fmt.Sprintf("Access { item_width: %d, items: &[%s] }", acs.ItemWidth, strings.Join(items, ", "))
+}
+
+func optAddr(addr *int64) string {
+	if addr == nil {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%d)", *addr)
+}
+
+func docComment(b *strings.Builder, doc string, ind string) {
+	if doc == "" {
+		return
+	}
+	for _, l := range strings.Split(doc, "\n") {
+		if l == "" {
+			fmt.Fprintf(b, "%s///\n", ind)
+		} else {
+			fmt.Fprintf(b, "%s/// %s\n", ind, l)
+		}
+	}
+}
+
+// genBlock generates block module.
+func genBlock(b *strings.Builder, blk *fn.Block, ind string) {
+	in := ind + indent
+	name := structName(blk.Name)
+
+	b.WriteString("\n")
+	docComment(b, blk.Doc, ind)
+	fmt.Fprintf(b, "%spub mod %s {\n", ind, snake(blk.Name))
+	fmt.Fprintf(b, "%s#![allow(clippy::identity_op, clippy::unnecessary_cast)]\n\n", in)
+	fmt.Fprintf(b, "%suse crate::access::{self, Access, Chunk};\n", in)
+	fmt.Fprintf(b, "%suse crate::Reg;\n\n", in)
+
+	fmt.Fprintf(b, "%s/// Block start address.\n", in)
+	if blk.IsArray {
+		fmt.Fprintf(b, "%s/// In case of array of blocks it is the start address of the first block.\n", in)
+	}
+	fmt.Fprintf(b, "%spub const START_ADDR: usize = %d;\n", in, blk.AddrSpace.Start)
+	fmt.Fprintf(b, "%s/// Block end address.\n", in)
+	if blk.IsArray {
+		fmt.Fprintf(b, "%s/// In case of array of blocks it is the end address of the first block.\n", in)
+	}
+	fmt.Fprintf(b, "%spub const END_ADDR: usize = %d;\n", in, blk.AddrSpace.End)
+	fmt.Fprintf(b, "%s/// Aligned block size.\n", in)
+	fmt.Fprintf(b, "%spub const SIZE: usize = %d;\n", in, blk.Sizes.Aligned)
+	if blk.IsArray {
+		fmt.Fprintf(b, "%s/// Number of blocks in the array.\n", in)
+		fmt.Fprintf(b, "%spub const COUNT: usize = %d;\n", in, blk.Count)
+	}
+
+	fmt.Fprintf(b, "\n%s#[derive(Clone, Copy, Debug)]\n", in)
+	fmt.Fprintf(b, "%spub struct %s {\n%s%sbase: *mut Reg,\n%s}\n", in, name, in, indent, in)
+	fmt.Fprintf(b, "\n%sunsafe impl Send for %s {}\n", in, name)
+
+	fmt.Fprintf(b, "\n%simpl %s {\n", in, name)
+	genNew(b, name, in+indent)
+	for _, sb := range blk.Subblocks {
+		genSubblock(b, blk, sb, in+indent)
+	}
+	for _, c := range blk.Configs {
+		if c.IsReadable() {
+			genRead(b, c.Func, c.Width, in+indent)
+		}
+		if c.IsWritable() {
+			genWrite(b, c.Func, c.Width, in+indent)
+		}
+	}
+	for _, i := range blk.Irqs {
+		genIrq(b, i, in+indent)
+	}
+	for _, m := range blk.Masks {
+		if m.IsReadable() {
+			genRead(b, m.Func, m.Width, in+indent)
+		}
+		if m.IsWritable() {
+			genWrite(b, m.Func, m.Width, in+indent)
+		}
+	}
+	for _, s := range blk.Statics {
+		genRead(b, s.Func, s.Width, in+indent)
+	}
+	for _, s := range blk.Statuses {
+		genRead(b, s.Func, s.Width, in+indent)
+	}
+	for _, p := range blk.Procs {
+		genProc(b, p, in+indent)
+	}
+	for _, s := range blk.Streams {
+		genStream(b, s, in+indent)
+	}
+	fmt.Fprintf(b, "%s}\n", in)
+
+	for _, c := range blk.Configs {
+		genField(b, c.Func, c.Width, c.Access, in, append(enumConsts(c.Values, c.Width), fieldMods(c.Fields, c.Width)...)...)
+	}
+	for _, i := range blk.Irqs {
+		if i.ClearAddr != nil {
+			genField(
+				b, i.Func, 1, i.Access, in,
+				"/// Explicit clear address, relative to the block start address.",
+				fmt.Sprintf("pub const CLEAR_ADDR: usize = %d;", *i.ClearAddr),
+			)
+		} else {
+			genField(b, i.Func, 1, i.Access, in)
+		}
+		if i.AddEnable {
+			enable := i.Func
+			enable.Name = i.Name + "_enable"
+			enable.Doc = ""
+			genField(b, enable, 1, i.EnableAccess, in)
+		}
+	}
+	for _, m := range blk.Masks {
+		genField(b, m.Func, m.Width, m.Access, in)
+	}
+	for _, s := range blk.Statics {
+		genField(b, s.Func, s.Width, s.Access, in, fieldMods(s.Fields, s.Width)...)
+	}
+	for _, s := range blk.Statuses {
+		genField(b, s.Func, s.Width, s.Access, in, append(enumConsts(s.Values, s.Width), fieldMods(s.Fields, s.Width)...)...)
+	}
+	for _, p := range blk.Procs {
+		genProcMod(b, p, in)
+	}
+	for _, s := range blk.Streams {
+		genStreamMod(b, s, in)
+	}
+
+	for _, sb := range blk.Subblocks {
+		genBlock(b, sb, in)
+	}
+
+	fmt.Fprintf(b, "%s}\n", ind)
+}
+
+func genNew(b *strings.Builder, name string, ind string) {
+	fmt.Fprintf(b, "%s/// Returns new %s accessing registers under the base pointer.\n", ind, name)
+	fmt.Fprintf(b, "%s///\n%s/// # Safety\n%s///\n", ind, ind, ind)
+	fmt.Fprintf(b, "%s/// The base must point to the block start address and must be valid\n", ind)
+	fmt.Fprintf(b, "%s/// for volatile reads and writes of the whole block address space.\n", ind)
+	fmt.Fprintf(b, "%spub const unsafe fn new(base: *mut Reg) -> Self {\n", ind)
+	fmt.Fprintf(b, "%s%sSelf { base }\n%s}\n", ind, indent, ind)
+}
+
+// genSubblock generates subblock accessor method.
+func genSubblock(b *strings.Builder, blk *fn.Block, sb *fn.Block, ind string) {
+	in := ind + indent
+	offset := sb.AddrSpace.Start - blk.AddrSpace.Start
+	typ := fmt.Sprintf("%s::%s", snake(sb.Name), structName(sb.Name))
+
+	b.WriteString("\n")
+	docComment(b, sb.Doc, ind)
+	if sb.IsArray {
+		fmt.Fprintf(b, "%spub fn %s(&self, idx: usize) -> %s {\n", ind, snake(sb.Name), typ)
+		fmt.Fprintf(
+			b, "%sassert!(idx < %d, \"index {} out of range [0:%d]\", idx);\n",
+			in, sb.Count, sb.Count-1,
+		)
+		fmt.Fprintf(b, "%sunsafe { %s::new(self.base.add(%d + idx * %d)) }\n", in, typ, offset, sb.Sizes.Aligned)
+	} else {
+		fmt.Fprintf(b, "%spub fn %s(&self) -> %s {\n", ind, snake(sb.Name), typ)
+		fmt.Fprintf(b, "%sunsafe { %s::new(self.base.add(%d)) }\n", in, typ, offset)
+	}
+	fmt.Fprintf(b, "%s}\n", ind)
+}
+
+// genRead generates read method for a data functionality.
+func genRead(b *strings.Builder, f fn.Func, width int64, ind string) {
+	b.WriteString("\n")
+	docComment(b, f.Doc, ind)
+	if f.IsArray {
+		fmt.Fprintf(b, "%spub fn %s(&self, idx: usize) -> %s {\n", ind, snake(f.Name), rustType(width))
+	} else {
+		fmt.Fprintf(b, "%spub fn %s(&self) -> %s {\n", ind, snake(f.Name), rustType(width))
+		fmt.Fprintf(b, "%s%slet idx = 0;\n", ind, indent)
+	}
+	fmt.Fprintf(
+		b, "%s%sunsafe { access::read(self.base, &%s::ACCESS, idx)%s }\n%s}\n",
+		ind, indent, snake(f.Name), cast(rustType(width)), ind,
+	)
+}
+
+// genWrite generates write method for a data functionality.
+func genWrite(b *strings.Builder, f fn.Func, width int64, ind string) {
+	b.WriteString("\n")
+	docComment(b, f.Doc, ind)
+	if f.IsArray {
+		fmt.Fprintf(b, "%spub fn set_%s(&self, idx: usize, v: %s) {\n", ind, strings.ToLower(f.Name), rustType(width))
+	} else {
+		fmt.Fprintf(b, "%spub fn set_%s(&self, v: %s) {\n", ind, strings.ToLower(f.Name), rustType(width))
+		fmt.Fprintf(b, "%s%slet idx = 0;\n", ind, indent)
+	}
+	fmt.Fprintf(
+		b, "%s%sunsafe { access::write(self.base, &%s::ACCESS, idx, v as u128) }\n%s}\n",
+		ind, indent, snake(f.Name), ind,
+	)
+}
+
+func genIrq(b *strings.Builder, irq *fn.Irq, ind string) {
+	genRead(b, irq.Func, 1, ind)
+
+	if irq.ClearAddr != nil {
+		b.WriteString("\n")
+		fmt.Fprintf(b, "%s/// Explicitly clears the %s irq.\n", ind, irq.Name)
+		if irq.IsArray {
+			fmt.Fprintf(b, "%spub fn clear_%s(&self, idx: usize) {\n", ind, strings.ToLower(irq.Name))
+		} else {
+			fmt.Fprintf(b, "%spub fn clear_%s(&self) {\n", ind, strings.ToLower(irq.Name))
+			fmt.Fprintf(b, "%s%slet idx = 0;\n", ind, indent)
+		}
+		fmt.Fprintf(
+			b, "%[1]s%[2]sunsafe { access::strobe(self.base, &%[3]s::ACCESS, idx, %[3]s::CLEAR_ADDR) }\n%[1]s}\n",
+			ind, indent, snake(irq.Name),
+		)
+	}
+
+	if irq.AddEnable {
+		enable := irq.Func
+		enable.Name = irq.Name + "_enable"
+		enable.Doc = ""
+		genRead(b, enable, 1, ind)
+		genWrite(b, enable, 1, ind)
+	}
+}
+
+func hexMask(width int64, shift int64) string {
+	m := "0x" + strings.Repeat("f", int(width/4))
+	if width%4 != 0 {
+		m = fmt.Sprintf("0x%x", (1<<(width%4))-1) + m[2:]
+	}
+	return fmt.Sprintf("%s << %d", m, shift)
+}
+
+// genField generates functionality module with address and bit-field constants.
+// Bit-field accessors are generated only if all items are placed within single register.
+// Extra lines are placed after the access constants.
+func genField(b *strings.Builder, f fn.Func, width int64, acs types.Access, ind string, extra ...string) {
+	in := ind + indent
+	typ := rustType(width)
+
+	b.WriteString("\n")
+	docComment(b, f.Doc, ind)
+	fmt.Fprintf(b, "%spub mod %s {\n", ind, snake(f.Name))
+	fmt.Fprintf(b, "%suse super::*;\n\n", in)
+	genAccessConsts(b, acs, in)
+	for _, l := range extra {
+		if l == "" {
+			b.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(b, "%s%s\n", in, l)
+	}
+
+	switch acs.Type {
+	case "SingleOneReg":
+		fmt.Fprintf(b, "\n%s/// Field mask within the register.\n", in)
+		fmt.Fprintf(b, "%spub const MASK: Reg = %s;\n", in, hexMask(width, acs.StartBit))
+		fmt.Fprintf(b, "\n%s/// Returns field value from the register value.\n", in)
+		fmt.Fprintf(b, "%spub const fn get(reg: Reg) -> %s {\n", in, typ)
+		fmt.Fprintf(b, "%s%s((reg & MASK) >> START_BIT) as %s\n%s}\n", in, indent, typ, in)
+		fmt.Fprintf(b, "\n%s/// Returns register value with the field value replaced.\n", in)
+		fmt.Fprintf(b, "%spub const fn set(reg: Reg, v: %s) -> Reg {\n", in, typ)
+		fmt.Fprintf(b, "%s%s(reg & !MASK) | (((v as Reg) << START_BIT) & MASK)\n%s}\n", in, indent, in)
+	case "ArrayOneReg":
+		fmt.Fprintf(b, "\n%s/// First item mask within the register.\n", in)
+		fmt.Fprintf(b, "%spub const MASK: Reg = %s;\n", in, hexMask(width, acs.StartBit))
+		fmt.Fprintf(b, "\n%s/// Returns value of the item with given index from the register value.\n", in)
+		fmt.Fprintf(b, "%spub const fn get(reg: Reg, idx: usize) -> %s {\n", in, typ)
+		fmt.Fprintf(b, "%s%sassert!(idx < COUNT);\n", in, indent)
+		fmt.Fprintf(
+			b, "%[1]s%[2]slet shift = idx as u32 * WIDTH;\n%[1]s%[2]s((reg & (MASK << shift)) >> (START_BIT + shift)) as %[3]s\n%[1]s}\n",
+			in, indent, typ,
+		)
+		fmt.Fprintf(b, "\n%s/// Returns register value with the value of the item with given index replaced.\n", in)
+		fmt.Fprintf(b, "%spub const fn set(reg: Reg, idx: usize, v: %s) -> Reg {\n", in, typ)
+		fmt.Fprintf(b, "%s%sassert!(idx < COUNT);\n", in, indent)
+		fmt.Fprintf(
+			b, "%[1]s%[2]slet shift = idx as u32 * WIDTH;\n%[1]s%[2]s(reg & !(MASK << shift)) | (((v as Reg) << (START_BIT + shift)) & (MASK << shift))\n%[1]s}\n",
+			in, indent,
+		)
+	}
+
+	fmt.Fprintf(b, "%s}\n", ind)
+}
+
+// enumConsts returns lines of the module with constants for named values of the functionality.
+// The module prevents conflicts with the access constants.
+func enumConsts(enum types.Enum, width int64) []string {
+	if len(enum) == 0 {
+		return nil
+	}
+	lines := []string{"", "/// Named values.", "pub mod values {"}
+	for _, ev := range enum {
+		lines = append(lines, fmt.Sprintf("%spub const %s: %s = %d;", indent, strings.ToUpper(ev.Name), rustType(width), ev.Value))
+	}
+	return append(lines, "}")
+}
+
+// fieldMods returns lines of the modules with bit field constants and accessors of the item value.
+// The item must be read or written as a whole, so the access stays atomic.
+func fieldMods(fields []*fn.Field, width int64) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	typ := rustType(width)
+	in := indent + indent
+	lines := []string{"", "/// Bit fields of the item value.", "pub mod fields {"}
+	for i, f := range fields {
+		ftyp := rustType(f.Width)
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines,
+			fmt.Sprintf("%spub mod %s {", indent, snake(f.Name)),
+			fmt.Sprintf("%s/// Start bit within the item.", in),
+			fmt.Sprintf("%spub const OFFSET: u32 = %d;", in, f.Offset),
+			fmt.Sprintf("%s/// Field width.", in),
+			fmt.Sprintf("%spub const WIDTH: u32 = %d;", in, f.Width),
+			fmt.Sprintf("%s/// Field mask within the item.", in),
+			fmt.Sprintf("%spub const MASK: %s = %s;", in, typ, hexMask(f.Width, f.Offset)),
+			"",
+			fmt.Sprintf("%s/// Returns field value from the item value.", in),
+			fmt.Sprintf("%spub const fn get(v: %s) -> %s {", in, typ, ftyp),
+			fmt.Sprintf("%s%s((v & MASK) >> OFFSET)%s", in, indent, cast(ftyp)),
+			fmt.Sprintf("%s}", in),
+			"",
+			fmt.Sprintf("%s/// Returns item value with the field value replaced.", in),
+			fmt.Sprintf("%spub const fn set(v: %s, f: %s) -> %s {", in, typ, ftyp, typ),
+			fmt.Sprintf("%s%s(v & !MASK) | (((f as %s) << OFFSET) & MASK)", in, indent, typ),
+			fmt.Sprintf("%s}", in),
+			fmt.Sprintf("%s}", indent),
+		)
+	}
+	return append(lines, "}")
+}
+
+func genAccessConsts(b *strings.Builder, acs types.Access, ind string) {
+	fmt.Fprintf(b, "%spub const ACCESS: Access = %s;\n\n", ind, access(acs))
+	fmt.Fprintf(b, "%s/// Address of the first register, relative to the block start address.\n", ind)
+	fmt.Fprintf(b, "%spub const ADDR: usize = %d;\n", ind, acs.StartAddr)
+	fmt.Fprintf(b, "%s/// Address of the last register, relative to the block start address.\n", ind)
+	fmt.Fprintf(b, "%spub const END_ADDR: usize = %d;\n", ind, acs.EndAddr)
+	fmt.Fprintf(b, "%s/// Start bit in the first register.\n", ind)
+	fmt.Fprintf(b, "%spub const START_BIT: u32 = %d;\n", ind, acs.StartBit)
+	fmt.Fprintf(b, "%s/// End bit in the last register.\n", ind)
+	fmt.Fprintf(b, "%spub const END_BIT: u32 = %d;\n", ind, acs.EndBit)
+	fmt.Fprintf(b, "%s/// Single item width.\n", ind)
+	fmt.Fprintf(b, "%spub const WIDTH: u32 = %d;\n", ind, acs.ItemWidth)
+	fmt.Fprintf(b, "%s/// Number of items.\n", ind)
+	fmt.Fprintf(b, "%spub const COUNT: usize = %d;\n", ind, acs.ItemCount)
+}
+
+func paramsDecl(params []*fn.Param) string {
+	decls := []string{"&self"}
+	for _, p := range params {
+		decls = append(decls, fmt.Sprintf("%s: %s", ident(p.Name), valType(p.Func, p.Width)))
+	}
+	return strings.Join(decls, ", ")
+}
+
+// genParams generates params conversion into values slices, and returns
+// the params list for the access::write_buffer function.
+func genParams(b *strings.Builder, mod string, params []*fn.Param, ind string) string {
+	args := []string{}
+	for i, p := range params {
+		if p.IsArray {
+			if p.Width > 64 {
+				fmt.Fprintf(b, "%slet p%d = %s;\n", ind, i, ident(p.Name))
+			} else {
+				fmt.Fprintf(b, "%slet p%d = %s.map(|v| v as u128);\n", ind, i, ident(p.Name))
+			}
+		} else {
+			if p.Width > 64 {
+				fmt.Fprintf(b, "%slet p%d = [%s];\n", ind, i, ident(p.Name))
+			} else {
+				fmt.Fprintf(b, "%slet p%d = [%s as u128];\n", ind, i, ident(p.Name))
+			}
+		}
+		args = append(args, fmt.Sprintf("(%s::%s::ACCESS, &p%d[..])", mod, snake(p.Name), i))
+	}
+	return "&[" + strings.Join(args, ", ") + "]"
+}
+
+func retsDecl(returns []*fn.Return) string {
+	types := []string{}
+	for _, r := range returns {
+		types = append(types, valType(r.Func, r.Width))
+	}
+	switch len(types) {
+	case 0:
+		return ""
+	case 1:
+		return " -> " + types[0]
+	default:
+		return " -> (" + strings.Join(types, ", ") + ")"
+	}
+}
+
+// genReturns generates reading of the returns buffer and conversion of read values into returns.
+func genReturns(b *strings.Builder, mod string, returns []*fn.Return, stbAddr string, ind string) {
+	rets := []string{}
+	vals := []string{}
+	for i, r := range returns {
+		fmt.Fprintf(b, "%slet mut r%d = [0u128; %d];\n", ind, i, r.Access.ItemCount)
+		rets = append(rets, fmt.Sprintf("(%s::%s::ACCESS, &mut r%d[..])", mod, snake(r.Name), i))
+		if r.IsArray {
+			if r.Width > 64 {
+				vals = append(vals, fmt.Sprintf("r%d", i))
+			} else {
+				vals = append(vals, fmt.Sprintf("r%d.map(|v| v as %s)", i, rustType(r.Width)))
+			}
+		} else {
+			vals = append(vals, fmt.Sprintf("r%d[0]%s", i, cast(rustType(r.Width))))
+		}
+	}
+	fmt.Fprintf(
+		b, "%sunsafe { access::read_buffer(self.base, &mut [%s], %s) };\n",
+		ind, strings.Join(rets, ", "), stbAddr,
+	)
+	if len(vals) == 0 {
+		return
+	} else if len(vals) == 1 {
+		fmt.Fprintf(b, "%s%s\n", ind, vals[0])
+	} else {
+		fmt.Fprintf(b, "%s(%s)\n", ind, strings.Join(vals, ", "))
+	}
+}
+
+// genProc generates proc method.
+// Procs with delay take the wait function, which is called after the params
+// are written and before the returns are read.
+func genProc(b *strings.Builder, p *fn.Proc, ind string) {
+	in := ind + indent
+	mod := snake(p.Name)
+
+	params := paramsDecl(p.Params)
+	if p.Delay != nil {
+		params += ", wait: impl FnOnce()"
+	}
+
+	b.WriteString("\n")
+	docComment(b, p.Doc, ind)
+	fmt.Fprintf(b, "%spub fn %s(%s)%s {\n", ind, snake(p.Name), params, retsDecl(p.Returns))
+
+	if len(p.Params) > 0 || p.CallAddr != nil {
+		args := genParams(b, mod, p.Params, in)
+		fmt.Fprintf(b, "%sunsafe { access::write_buffer(self.base, %s, %s::CALL_ADDR) };\n", in, args, mod)
+	}
+	if p.Delay != nil {
+		fmt.Fprintf(b, "%swait();\n", in)
+	}
+	if len(p.Returns) > 0 || p.ExitAddr != nil {
+		genReturns(b, mod, p.Returns, mod+"::EXIT_ADDR", in)
+	}
+
+	fmt.Fprintf(b, "%s}\n", ind)
+}
+
+// genStream generates stream method.
+// The stream strobe is generated by the access to the StbAddr,
+// which is always the last accessed register.
+func genStream(b *strings.Builder, s *fn.Stream, ind string) {
+	in := ind + indent
+	mod := snake(s.Name)
+
+	b.WriteString("\n")
+	docComment(b, s.Doc, ind)
+	fmt.Fprintf(b, "%spub fn %s(%s)%s {\n", ind, snake(s.Name), paramsDecl(s.Params), retsDecl(s.Returns))
+	if s.IsDownstream() {
+		args := genParams(b, mod, s.Params, in)
+		fmt.Fprintf(b, "%sunsafe { access::write_buffer(self.base, %s, Some(%s::STB_ADDR)) };\n", in, args, mod)
+	} else {
+		genReturns(b, mod, s.Returns, fmt.Sprintf("Some(%s::STB_ADDR)", mod), in)
+	}
+	fmt.Fprintf(b, "%s}\n", ind)
+}
+
+func genParamMods(b *strings.Builder, params []*fn.Param, returns []*fn.Return, ind string) {
+	for _, p := range params {
+		genField(b, p.Func, p.Width, p.Access, ind, enumConsts(p.Values, p.Width)...)
+	}
+	for _, r := range returns {
+		genField(b, r.Func, r.Width, r.Access, ind)
+	}
+}
+
+// genProcMod generates proc module with call and exit addresses and params and returns modules.
+func genProcMod(b *strings.Builder, p *fn.Proc, ind string) {
+	in := ind + indent
+
+	b.WriteString("\n")
+	docComment(b, p.Doc, ind)
+	fmt.Fprintf(b, "%spub mod %s {\n", ind, snake(p.Name))
+	if len(p.Params) > 0 || len(p.Returns) > 0 {
+		fmt.Fprintf(b, "%suse super::*;\n\n", in)
+	}
+	fmt.Fprintf(b, "%s/// Call address, relative to the block start address.\n", in)
+	fmt.Fprintf(b, "%spub const CALL_ADDR: Option<usize> = %s;\n", in, optAddr(p.CallAddr))
+	fmt.Fprintf(b, "%s/// Exit address, relative to the block start address.\n", in)
+	fmt.Fprintf(b, "%spub const EXIT_ADDR: Option<usize> = %s;\n", in, optAddr(p.ExitAddr))
+	if p.Delay != nil {
+		fmt.Fprintf(b, "%s/// Delay between the call and the exit in nanoseconds.\n", in)
+		fmt.Fprintf(b, "%spub const DELAY_NS: u64 = %d;\n", in, p.Delay.S*1000000000+p.Delay.Ns)
+	}
+	genParamMods(b, p.Params, p.Returns, in)
+	fmt.Fprintf(b, "%s}\n", ind)
+}
+
+// genStreamMod generates stream module with strobe address and params and returns modules.
+func genStreamMod(b *strings.Builder, s *fn.Stream, ind string) {
+	in := ind + indent
+
+	b.WriteString("\n")
+	docComment(b, s.Doc, ind)
+	fmt.Fprintf(b, "%spub mod %s {\n", ind, snake(s.Name))
+	if len(s.Params) > 0 || len(s.Returns) > 0 {
+		fmt.Fprintf(b, "%suse super::*;\n\n", in)
+	}
+	fmt.Fprintf(b, "%s/// Strobe address, relative to the block start address.\n", in)
+	fmt.Fprintf(b, "%spub const STB_ADDR: usize = %d;\n", in, s.StbAddr)
+	genParamMods(b, s.Params, s.Returns, in)
+	fmt.Fprintf(b, "%s}\n", ind)
+}
@@ -0,0 +1,26 @@
+package rust
+
+import (
+	"testing"
+)
+
+func TestHexMask(t *testing.T) {
+	var tests = []struct {
+		width int64
+		shift int64
+		want  string
+	}{
+		{1, 5, "0x1 << 5"},
+		{4, 0, "0xf << 0"},
+		{8, 3, "0xff << 3"},
+		{10, 0, "0x3ff << 0"},
+		{32, 0, "0xffffffff << 0"},
+	}
+
+	for i, test := range tests {
+		got := hexMask(test.width, test.shift)
+		if got != test.want {
+			t.Errorf("[%d]: got %q, want %q", i, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package wavedrom
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Fill colors of the field types.
+var colors = map[int]string{
+	0: "#ffffff",
+	2: "#ffd8b5",
+	3: "#ffffa0",
+	4: "#b6ffb5",
+	5: "#b5fffe",
+	6: "#b5d0ff",
+	7: "#e2b5ff",
+}
+
+const (
+	margin     = 10
+	numsHeight = 16 // Height of the bit numbers row.
+	boxHeight  = 32
+	attrHeight = 16 // Height of the attribute row.
+	fontSize   = 12
+	charWidth  = 7 // Approximate width of a character for the font size.
+)
+
+// render renders diagram as SVG image.
+// Fields are drawn from the most significant bit on the left.
+// Strobes, if any, are listed below the diagram.
+func render(d diagram, strobes []string) string {
+	cell := 28
+	if d.Config.Bits > 32 {
+		cell = 14
+	}
+
+	width := 2*margin + int(d.Config.Bits)*cell
+	height := 2*margin + numsHeight + boxHeight + attrHeight
+	if len(strobes) > 0 {
+		height += attrHeight
+	}
+
+	b := strings.Builder{}
+	fmt.Fprintf(
+		&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[2]d" viewBox="0 0 %[1]d %[2]d">`+"\n",
+		width, height,
+	)
+	fmt.Fprintf(&b, `<g font-family="monospace" font-size="%d" text-anchor="middle">`+"\n", fontSize)
+
+	top := margin + numsHeight
+	bit := int64(0)
+	for _, f := range d.Reg {
+		endBit := bit + f.Bits - 1
+		x := margin + int(d.Config.Bits-1-endBit)*cell
+		w := int(f.Bits) * cell
+
+		fmt.Fprintf(
+			&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="black"/>`+"\n",
+			x, top, w, boxHeight, colors[f.Type],
+		)
+
+		// Bit numbers.
+		baseline := top - 4
+		if f.Bits == 1 {
+			fmt.Fprintf(&b, `<text x="%d" y="%d">%d</text>`+"\n", x+w/2, baseline, bit)
+		} else {
+			fmt.Fprintf(&b, `<text x="%d" y="%d">%d</text>`+"\n", x+cell/2, baseline, endBit)
+			fmt.Fprintf(&b, `<text x="%d" y="%d">%d</text>`+"\n", x+w-cell/2, baseline, bit)
+		}
+
+		if f.Name != "" {
+			size := fontSize
+			if fits := (w - 4) / len(f.Name); fits < charWidth {
+				size = fontSize * fits / charWidth
+				if size < 6 {
+					size = 6
+				}
+			}
+			fmt.Fprintf(
+				&b, `<text x="%d" y="%d" font-size="%d">%s</text>`+"\n",
+				x+w/2, top+boxHeight/2+size/3, size, html.EscapeString(f.Name),
+			)
+		}
+		if f.Attr != "" {
+			fmt.Fprintf(
+				&b, `<text x="%d" y="%d">%s</text>`+"\n",
+				x+w/2, top+boxHeight+attrHeight-4, html.EscapeString(f.Attr),
+			)
+		}
+
+		bit += f.Bits
+	}
+
+	if len(strobes) > 0 {
+		fmt.Fprintf(
+			&b, `<text x="%d" y="%d" text-anchor="start">strobe: %s</text>`+"\n",
+			margin, top+boxHeight+2*attrHeight-4, html.EscapeString(strings.Join(strobes, ", ")),
+		)
+	}
+
+	b.WriteString("</g>\n</svg>\n")
+
+	return b.String()
+}
@@ -0,0 +1,115 @@
+// Package wavedrom implements WaveDrom register bit-field diagrams generator.
+//
+// For every occupied register the generator emits WaveDrom 'reg' JSON description.
+// Optionally, the diagram is also rendered as SVG image.
+// Files are placed in directories reflecting the block hierarchy,
+// and are named after the register address relative to the block start address.
+package wavedrom
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/regmap"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+// Field types used for field colors.
+var fieldTypes = map[string]int{
+	"config": 2, "mask": 3, "irq": 4, "static": 5, "status": 6, "param": 7, "return": 7,
+}
+
+type field struct {
+	Name string `json:"name,omitempty"`
+	Bits int64  `json:"bits"`
+	Attr string `json:"attr,omitempty"`
+	Type int    `json:"type,omitempty"`
+}
+
+type config struct {
+	Bits  int64 `json:"bits"`
+	Lanes int   `json:"lanes"`
+}
+
+type diagram struct {
+	Reg    []field `json:"reg"`
+	Config config  `json:"config"`
+}
+
+// Generate generates diagrams for all registers of the bus and writes them
+// into the directory under path.
+// If svg is true, diagrams are also rendered as SVG images.
+func Generate(bus *fn.Block, path string, svg bool) error {
+	digits := len(fmt.Sprintf("%X", bus.AddrSpace.End))
+
+	err := genBlock(bus, filepath.Join(path, bus.Name), bus.Width, digits, svg)
+	if err != nil {
+		return fmt.Errorf("generate wavedrom: %v", err)
+	}
+
+	return nil
+}
+
+// makeDiagram makes register diagram.
+// Fields are placed starting from the least significant bit, gaps have no name.
+func makeDiagram(r regmap.Register, width int64) diagram {
+	d := diagram{Config: config{Bits: width, Lanes: 1}}
+
+	bit := int64(0)
+	for _, f := range r.Fields {
+		if f.Bit > bit {
+			d.Reg = append(d.Reg, field{Bits: f.Bit - bit})
+		}
+		d.Reg = append(d.Reg, field{
+			Name: f.Label(),
+			Bits: f.Width,
+			Attr: f.Type,
+			Type: fieldTypes[f.Type],
+		})
+		bit = f.EndBit() + 1
+	}
+	if bit < width {
+		d.Reg = append(d.Reg, field{Bits: width - bit})
+	}
+
+	return d
+}
+
+func genBlock(blk *fn.Block, dir string, width int64, digits int, svg bool) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range regmap.Make(blk) {
+		d := makeDiagram(r, width)
+		name := filepath.Join(dir, fmt.Sprintf("0x%0*X", digits, r.Addr))
+
+		bytes, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(name+".json", append(bytes, '\n'), 0644)
+		if err != nil {
+			return err
+		}
+
+		if svg {
+			err = os.WriteFile(name+".svg", []byte(render(d, r.Strobes)), 0644)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, sb := range blk.Subblocks {
+		err = genBlock(sb, filepath.Join(dir, sb.Name), width, digits, svg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package wavedrom
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/regmap"
+)
+
+func TestMakeDiagram(t *testing.T) {
+	r := regmap.Register{
+		Fields: []regmap.Field{
+			{Name: "a", Type: "config", ItemWidth: 4, Bit: 2, Width: 4},
+			{Name: "b", Type: "status", IsArray: true, Idx: 1, ItemWidth: 10, Bit: 6, Width: 2, Shift: 8},
+		},
+	}
+
+	want := diagram{
+		Reg: []field{
+			{Bits: 2},
+			{Name: "a", Bits: 4, Attr: "config", Type: 2},
+			{Name: "b[1][9:8]", Bits: 2, Attr: "status", Type: 6},
+			{Bits: 8},
+		},
+		Config: config{Bits: 16, Lanes: 1},
+	}
+
+	got := makeDiagram(r, 16)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -63,9 +63,17 @@ func applyBlackboxType(bb *fn.Blackbox, typ prs.Functionality) error {
 		}
 
 		switch p.Name {
+		case "addr":
+			if bb.Addr != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "addr")
+			}
+			addr := int64(v.(val.Int))
+			bb.Addr = &addr
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "size":
 			if bb.Size != 0 {
-				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "masters")
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "size")
 			}
 			bb.Size = int64(v.(val.Int))
 		default:
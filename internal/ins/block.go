@@ -5,13 +5,19 @@ import (
 	"log"
 
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/block"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/constContainer"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/group"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
 )
 
+type blockDiary struct {
+	forbidRMWSet bool
+}
+
 func insBlock(typeChain []prs.Functionality) (*fn.Block, error) {
 	typeChainStr := fmt.Sprintf("debug: instantiating block, type chain: %s", typeChain[0].Name())
 	for i := 1; i < len(typeChain); i++ {
@@ -26,13 +32,15 @@ func insBlock(typeChain []prs.Functionality) (*fn.Block, error) {
 	blk := fn.Block{}
 	blk.Func = f
 
+	diary := blockDiary{}
+
 	tci := typeChainIter(typeChain)
 	for {
 		typ, ok := tci()
 		if !ok {
 			break
 		}
-		err := applyBlockType(&blk, typ)
+		err := applyBlockType(&blk, typ, typeChain[0].Type(), &diary)
 		if err != nil {
 			return nil, fmt.Errorf("%v", err)
 		}
@@ -43,7 +51,8 @@ func insBlock(typeChain []prs.Functionality) (*fn.Block, error) {
 	return &blk, nil
 }
 
-func applyBlockType(blk *fn.Block, typ prs.Functionality) error {
+// baseType is required to distinguish the main bus from subblocks.
+func applyBlockType(blk *fn.Block, typ prs.Functionality, baseType string, diary *blockDiary) error {
 	for _, p := range typ.Props() {
 		if err := util.IsValidProperty(p.Name, "bus"); err != nil {
 			return fmt.Errorf(": %v", err)
@@ -58,16 +67,43 @@ func applyBlockType(blk *fn.Block, typ prs.Functionality) error {
 		}
 
 		switch p.Name {
+		case "addr", "base-address":
+			// Block properties are validated against bus properties,
+			// however 'addr' makes no sense for the main bus.
+			if baseType == "bus" && p.Name == "addr" {
+				return tok.Error{
+					Msg:  "addr property cannot be set for the main bus, use base-address property",
+					Toks: []tok.Token{p.NameTok},
+				}
+			}
+			if blk.Addr != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), p.Name)
+			}
+			addr := int64(v.(val.Int))
+			blk.Addr = &addr
 		case "align":
 			if blk.Align != 0 {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "align")
 			}
 			blk.Align = int64(v.(val.Int))
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
+		case "forbid-rmw":
+			if diary.forbidRMWSet {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "forbid-rmw")
+			}
+			blk.ForbidRMW = bool(v.(val.Bool))
+			diary.forbidRMWSet = true
 		case "masters":
 			if blk.Masters != 0 {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "masters")
 			}
 			blk.Masters = int64(v.(val.Int))
+		case "packing":
+			if blk.Packing != "" {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "packing")
+			}
+			blk.Packing = string(v.(val.Str))
 		case "reset":
 			if blk.Reset != "" {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "reset")
@@ -107,6 +143,9 @@ func applyBlockType(blk *fn.Block, typ prs.Functionality) error {
 		}
 
 		f := insFunctionality(s.(prs.Functionality))
+		if f == nil {
+			continue
+		}
 
 		if !util.IsValidInnerType(f.Type(), "block") {
 			return fmt.Errorf(
@@ -117,6 +156,15 @@ func applyBlockType(blk *fn.Block, typ prs.Functionality) error {
 		if block.HasFunctionality(blk, f.GetName()) {
 			return fmt.Errorf(funcWithNameAlreadyInstMsg, f.GetName())
 		}
+		if grp, ok := f.(*fn.Group); ok {
+			err := checkBlockGroup(blk, grp)
+			if err != nil {
+				return tok.Error{
+					Msg:  fmt.Sprintf("%v", err),
+					Toks: []tok.Token{s.Tok()},
+				}
+			}
+		}
 		addBlockInnerElement(blk, f)
 	}
 
@@ -144,6 +192,8 @@ func addBlockInnerElement(blk *fn.Block, f any) {
 		block.AddIrq(blk, f)
 	case (*fn.Mask):
 		block.AddMask(blk, f)
+	case (*fn.Memory):
+		block.AddMemory(blk, f)
 	case (*fn.Proc):
 		block.AddProc(blk, f)
 	case (*fn.Static):
@@ -158,3 +208,30 @@ func addBlockInnerElement(blk *fn.Block, f any) {
 		panic("should never happen")
 	}
 }
+
+// checkBlockGroup checks whether group can be placed in the block.
+// Group functionalities share the namespace with the block functionalities,
+// as they are accessed as regular block functionalities.
+func checkBlockGroup(blk *fn.Block, grp *fn.Group) error {
+	if grp.IsArray {
+		return fmt.Errorf("group '%s' cannot be an array", grp.Name)
+	}
+
+	if len(grp.Params) > 0 || len(grp.Returns) > 0 {
+		return fmt.Errorf(
+			"group '%s' cannot be instantiated in block, param and return groups are valid only within proc or stream",
+			grp.Name,
+		)
+	}
+
+	for _, f := range group.Functionalities(grp) {
+		if block.HasFunctionality(blk, f.GetName()) {
+			return fmt.Errorf(
+				"cannot instantiate group '%s', functionality '%s' is already instantiated in one of ancestor types",
+				grp.Name, f.GetName(),
+			)
+		}
+	}
+
+	return nil
+}
@@ -38,7 +38,7 @@ func checkProp(prop prs.Prop) error {
 				Toks: []tok.Token{prop.ValueTok},
 			}
 		}
-	case "add-enable", "atomic", "byte-write-enable", "virtual":
+	case "add-enable", "atomic", "byte-write-enable", "enabled", "forbid-rmw", "virtual":
 		if _, ok := pv.(val.Bool); !ok {
 			return tok.Error{
 				Msg:  fmt.Sprintf(invalidTypeMsg, name, "bool", pv.Type()),
@@ -129,6 +129,22 @@ func checkProp(prop prs.Prop) error {
 				Toks: []tok.Token{prop.ValueTok},
 			}
 		}
+	case "packing":
+		v, ok := pv.(val.Str)
+		if !ok {
+			return tok.Error{
+				Msg:  fmt.Sprintf(invalidTypeMsg, name, "string", pv.Type()),
+				Toks: []tok.Token{prop.ValueTok},
+			}
+		}
+		if v != "Compact" && v != "One Per Register" && v != "Natural Alignment" {
+			return tok.Error{
+				Msg: fmt.Sprintf(
+					"packing property must be \"Compact\", \"One Per Register\" or \"Natural Alignment\", current value %q", v,
+				),
+				Toks: []tok.Token{prop.ValueTok},
+			}
+		}
 	case "range":
 		switch v := pv.(type) {
 		case val.Int:
@@ -226,7 +242,39 @@ func checkProp(prop prs.Prop) error {
 				Toks: []tok.Token{prop.ValueTok},
 			}
 		}
-	case "read-latency", "size", "width":
+	case "values":
+		v, ok := pv.(val.List)
+		if !ok {
+			return tok.Error{
+				Msg:  fmt.Sprintf(invalidTypeMsg, name, "[integer]", pv.Type()),
+				Toks: []tok.Token{prop.ValueTok},
+			}
+		}
+		if len(v) == 0 {
+			return tok.Error{
+				Msg:  "empty values property value list",
+				Toks: []tok.Token{prop.ValueTok},
+			}
+		}
+		for i, x := range v {
+			x, ok := x.(val.Int)
+			if !ok {
+				return tok.Error{
+					Msg: fmt.Sprintf(
+						"all values in values property list must be of type integer, value with index %d is of type %s",
+						i, v[i].Type(),
+					),
+					Toks: []tok.Token{prop.ValueTok},
+				}
+			}
+			if x < 0 {
+				return tok.Error{
+					Msg:  fmt.Sprintf("negative value %d with index %d in values property list", x, i),
+					Toks: []tok.Token{prop.ValueTok},
+				}
+			}
+		}
+	case "addr", "base-address", "read-latency", "size", "width":
 		v, ok := pv.(val.Int)
 		if !ok {
 			return tok.Error{
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
@@ -14,11 +15,14 @@ type configDiary struct {
 	atomicSet   bool
 	initValSet  bool
 	initVal     val.Value
+	initValTok  tok.Token
 	rangeSet    bool
 	readValSet  bool
 	readVal     val.Value
 	resetValSet bool
 	resetVal    val.Value
+	resetValTok tok.Token
+	valuesTok   tok.Token
 	widthSet    bool
 }
 
@@ -45,6 +49,23 @@ func insConfig(typeChain []prs.Functionality) (*fn.Config, error) {
 	}
 
 	fillConfigProps(&cfg, diary)
+
+	if err := checkFields(cfg.Fields, cfg.Width); err != nil {
+		last := typeChain[len(typeChain)-1]
+		return nil, tok.Error{
+			Msg:  fmt.Sprintf("'%s' %v", last.Name(), err),
+			Toks: []tok.Token{last.Tok()},
+		}
+	}
+
+	if cfg.Values != nil {
+		if err := checkEnum(cfg.Values, cfg.Width, cfg.Range); err != nil {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("values property: %v", err),
+				Toks: []tok.Token{diary.valuesTok},
+			}
+		}
+	}
 	err = fillConfigValues(&cfg, diary)
 	if err != nil {
 		return nil, err
@@ -68,17 +89,31 @@ func applyConfigType(cfg *fn.Config, typ prs.Functionality, diary *configDiary)
 		}
 
 		switch p.Name {
+		case "access":
+			if cfg.AccessMode != "" {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "access")
+			}
+			cfg.AccessMode = string(v.(val.Str))
+		case "addr":
+			if cfg.Addr != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "addr")
+			}
+			addr := int64(v.(val.Int))
+			cfg.Addr = &addr
 		case "atomic":
 			if diary.atomicSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "atomic")
 			}
 			cfg.Atomic = (bool(v.(val.Bool)))
 			diary.atomicSet = true
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "init-value":
 			if diary.initValSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "init-value")
 			}
 			diary.initVal = v
+			diary.initValTok = p.ValueTok
 			diary.initValSet = true
 		case "range":
 			if diary.rangeSet {
@@ -118,7 +153,17 @@ func applyConfigType(cfg *fn.Config, typ prs.Functionality, diary *configDiary)
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "reset-value")
 			}
 			diary.resetVal = v
+			diary.resetValTok = p.ValueTok
 			diary.resetValSet = true
+		case "values":
+			if cfg.Values != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "values")
+			}
+			cfg.Values, err = makeEnum(p, v)
+			if err != nil {
+				return err
+			}
+			diary.valuesTok = p.ValueTok
 		case "width":
 			if diary.widthSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "width")
@@ -133,18 +178,26 @@ func applyConfigType(cfg *fn.Config, typ prs.Functionality, diary *configDiary)
 		}
 	}
 
-	return nil
+	return insInnerFields(&cfg.Fields, typ, "config")
 }
 
 func fillConfigProps(cfg *fn.Config, diary configDiary) {
+	if cfg.AccessMode == "" {
+		cfg.AccessMode = "Read Write"
+	}
 	if !diary.atomicSet {
 		cfg.Atomic = true
 	}
+	fieldsWidth := placeFields(cfg.Fields)
 	if !diary.widthSet {
-		if !diary.rangeSet {
-			cfg.Width = busWidth
-		} else {
+		if diary.rangeSet {
 			cfg.Width = cfg.Range.BitWidth()
+		} else if cfg.Values != nil {
+			cfg.Width = enumWidth(cfg.Values)
+		} else if cfg.Fields != nil {
+			cfg.Width = fieldsWidth
+		} else {
+			cfg.Width = busWidth
 		}
 	}
 }
@@ -155,6 +208,12 @@ func fillConfigValues(cfg *fn.Config, diary configDiary) error {
 		if err != nil {
 			return fmt.Errorf("'init-value': %v", err)
 		}
+		if err := checkEnumValue(cfg.Values, diary.initVal); err != nil {
+			return tok.Error{
+				Msg:  fmt.Sprintf("init-value property: %v", err),
+				Toks: []tok.Token{diary.initValTok},
+			}
+		}
 		cfg.InitValue = types.MakeBitStr(val)
 	}
 
@@ -163,6 +222,12 @@ func fillConfigValues(cfg *fn.Config, diary configDiary) error {
 		if err != nil {
 			return fmt.Errorf("'reset-value': %v", err)
 		}
+		if err := checkEnumValue(cfg.Values, diary.resetVal); err != nil {
+			return tok.Error{
+				Msg:  fmt.Sprintf("reset-value property: %v", err),
+				Toks: []tok.Token{diary.resetValTok},
+			}
+		}
 		cfg.ResetValue = types.MakeBitStr(val)
 	}
 
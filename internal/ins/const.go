@@ -1,6 +1,8 @@
 package ins
 
 import (
+	"log"
+
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/constContainer"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/pkg"
@@ -28,7 +30,7 @@ func constifyPkg(pp *prs.Package) *pkg.Package {
 	for _, c := range pp.Consts {
 		v, err := c.Value.Eval()
 		if err != nil {
-			panic("not yet implemented")
+			log.Fatalf("%v", err)
 		}
 		constContainer.AddConst(&p.Consts, c.Name(), v)
 	}
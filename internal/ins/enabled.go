@@ -0,0 +1,56 @@
+package ins
+
+import (
+	"fmt"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
+)
+
+// isEnabled evaluates the 'enabled' property of the functionality with given type chain.
+// The property is evaluated with type parameters in scope, so it can depend on arguments.
+// Functionalities without the 'enabled' property are enabled.
+func isEnabled(typeChain []prs.Functionality) (bool, error) {
+	baseType := typeChain[0].Type()
+	// Invalid 'enabled' property is reported when the type is applied.
+	if util.IsValidProperty("enabled", baseType) != nil {
+		return true, nil
+	}
+
+	// typeChainIter overwrites resolved arguments of the last functionality in the chain.
+	// Restore them, so that the type chain can be iterated again during the instantiation.
+	last := typeChain[len(typeChain)-1]
+	defer last.SetResolvedArgs(last.ResolvedArgs())
+
+	enabled := true
+	enabledSet := false
+
+	tci := typeChainIter(typeChain)
+	for {
+		typ, ok := tci()
+		if !ok {
+			break
+		}
+
+		p, ok := typ.Props().Get("enabled")
+		if !ok {
+			continue
+		}
+		if enabledSet {
+			return false, fmt.Errorf(propAlreadySetMsg, p.Loc(), "enabled")
+		}
+		if err := checkProp(p); err != nil {
+			return false, err
+		}
+
+		v, err := p.Value.Eval()
+		if err != nil {
+			return false, err
+		}
+		enabled = bool(v.(val.Bool))
+		enabledSet = true
+	}
+
+	return enabled, nil
+}
@@ -0,0 +1,111 @@
+package ins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// makeEnum makes enum from the 'values' property.
+// The property value must be a list of constant identifiers,
+// names of the constants become names of the values.
+// As the values are regular constants, they can be used in the '*-value' properties.
+func makeEnum(prop prs.Prop, v val.Value) (types.Enum, error) {
+	list, ok := prop.Value.(prs.List)
+	if !ok {
+		return nil, tok.Error{
+			Msg:  "values property must be a list of constant identifiers",
+			Toks: []tok.Token{prop.ValueTok},
+		}
+	}
+
+	enum := types.Enum{}
+	for i, e := range list.Exprs() {
+		var name string
+		switch e := e.(type) {
+		case prs.DeclaredIdentifier:
+			name = e.Name()
+		case prs.QualifiedIdentifier:
+			_, name, _ = strings.Cut(e.Name(), ".")
+		default:
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("value with index %d in values property list is not a constant identifier", i),
+				Toks: []tok.Token{prop.ValueTok},
+			}
+		}
+
+		x := int64(v.(val.List)[i].(val.Int))
+		for _, ev := range enum {
+			if ev.Name == name {
+				return nil, tok.Error{
+					Msg:  fmt.Sprintf("duplicated name '%s' in values property list", name),
+					Toks: []tok.Token{prop.ValueTok},
+				}
+			}
+			if ev.Value == x {
+				return nil, tok.Error{
+					Msg:  fmt.Sprintf("'%s' and '%s' in values property list have the same value %d", ev.Name, name, x),
+					Toks: []tok.Token{prop.ValueTok},
+				}
+			}
+		}
+
+		enum = append(enum, types.EnumValue{Name: name, Value: x})
+	}
+
+	return enum, nil
+}
+
+// enumWidth returns width required to represent all enum values.
+func enumWidth(enum types.Enum) int64 {
+	return max(types.SingleRange{Start: 0, End: enum.MaxValue()}.BitWidth(), 1)
+}
+
+// checkEnum checks whether all enum values can be represented with the given width,
+// and whether they are within the given range.
+// The range is not checked if it is nil.
+func checkEnum(enum types.Enum, width int64, rng types.Range) error {
+	for _, ev := range enum {
+		if width < 63 && ev.Value >= int64(1)<<width {
+			return fmt.Errorf("value '%s' (%d) does not fit into width %d", ev.Name, ev.Value, width)
+		}
+		if rng != nil && !inRange(ev.Value, rng) {
+			return fmt.Errorf("value '%s' (%d) is not within the range", ev.Name, ev.Value)
+		}
+	}
+	return nil
+}
+
+func inRange(v int64, rng types.Range) bool {
+	switch r := rng.(type) {
+	case types.SingleRange:
+		return r.Start <= v && v <= r.End
+	case types.ArrayRange:
+		for _, sr := range r {
+			if sr.Start <= v && v <= sr.End {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkEnumValue checks whether the integer '*-value' property value is one of the enum values.
+// Bit string values are not checked, as they may contain meta values.
+func checkEnumValue(enum types.Enum, v val.Value) error {
+	if enum == nil {
+		return nil
+	}
+	i, ok := v.(val.Int)
+	if !ok {
+		return nil
+	}
+	if _, ok := enum.Name(int64(i)); !ok {
+		return fmt.Errorf("value %d is not one of the values property values", i)
+	}
+	return nil
+}
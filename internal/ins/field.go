@@ -0,0 +1,145 @@
+package ins
+
+import (
+	"fmt"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+type fieldDiary struct {
+	widthSet bool
+}
+
+func insField(typeChain []prs.Functionality) (*fn.Field, error) {
+	f, err := makeFunctionality(typeChain)
+	if err != nil {
+		return nil, fmt.Errorf("%v", err)
+	}
+	fld := fn.Field{}
+	fld.Func = f
+
+	diary := fieldDiary{}
+
+	if f.IsArray {
+		last := typeChain[len(typeChain)-1]
+		return nil, tok.Error{
+			Msg:  fmt.Sprintf("field '%s' cannot be an array", f.Name),
+			Toks: []tok.Token{last.Tok()},
+		}
+	}
+
+	tci := typeChainIter(typeChain)
+	for {
+		typ, ok := tci()
+		if !ok {
+			break
+		}
+		err := applyFieldType(&fld, typ, &diary)
+		if err != nil {
+			return nil, fmt.Errorf("%v", err)
+		}
+	}
+
+	if !diary.widthSet {
+		fld.Width = 1
+	}
+
+	return &fld, nil
+}
+
+func applyFieldType(fld *fn.Field, typ prs.Functionality, diary *fieldDiary) error {
+	for _, p := range typ.Props() {
+		if err := util.IsValidProperty(p.Name, "field"); err != nil {
+			return fmt.Errorf(": %v", err)
+		}
+		if err := checkProp(p); err != nil {
+			return err
+		}
+
+		v, err := p.Value.Eval()
+		if err != nil {
+			return err
+		}
+
+		switch p.Name {
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
+		case "width":
+			if diary.widthSet {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "width")
+			}
+			w := v.(val.Int)
+			if w < 1 {
+				return tok.Error{
+					Msg:  fmt.Sprintf("field width property must be positive, current value %d", w),
+					Toks: []tok.Token{p.ValueTok},
+				}
+			}
+			fld.Width = int64(w)
+			diary.widthSet = true
+		default:
+			panic(fmt.Sprintf("unhandled '%s' property", p.Name))
+		}
+	}
+
+	return nil
+}
+
+// insInnerFields instantiates fields declared in the type of the functionality with given base type.
+func insInnerFields(fields *[]*fn.Field, typ prs.Functionality, baseType string) error {
+	for _, s := range typ.Symbols() {
+		pe, ok := s.(*prs.Inst)
+		if !ok {
+			continue
+		}
+
+		f := insFunctionality(pe)
+		if f == nil {
+			continue
+		}
+
+		if !util.IsValidInnerType(f.Type(), baseType) {
+			return fmt.Errorf(invalidInnerTypeMsg, f.GetName(), f.Type(), baseType)
+		}
+
+		for _, fld := range *fields {
+			if fld.Name == f.GetName() {
+				return fmt.Errorf(funcWithNameAlreadyInstMsg, f.GetName())
+			}
+		}
+
+		*fields = append(*fields, f.(*fn.Field))
+	}
+
+	return nil
+}
+
+// placeFields sets offsets of fields in the declaration order, starting from the least significant bit.
+// It returns the width occupied by all fields.
+func placeFields(fields []*fn.Field) int64 {
+	offset := int64(0)
+	for _, f := range fields {
+		f.Offset = offset
+		offset += f.Width
+	}
+	return offset
+}
+
+// checkFields checks whether fields fit into the item of given width.
+func checkFields(fields []*fn.Field, width int64) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	last := fields[len(fields)-1]
+	if last.EndBit() >= width {
+		return fmt.Errorf(
+			"fields width %d is greater than the width %d, field '%s' ends at bit %d",
+			last.EndBit()+1, width, last.Name, last.EndBit(),
+		)
+	}
+	return nil
+}
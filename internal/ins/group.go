@@ -78,6 +78,8 @@ func applyGroupType(grp *fn.Group, typ prs.Functionality, diary *groupDiary) err
 		}
 
 		switch p.Name {
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "virtual":
 			if diary.virtualSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "virtual")
@@ -113,6 +115,9 @@ func applyGroupType(grp *fn.Group, typ prs.Functionality, diary *groupDiary) err
 		}
 
 		f := insFunctionality(sym.(prs.Functionality))
+		if f == nil {
+			continue
+		}
 
 		if !util.IsValidInnerType(f.Type(), "group") {
 			return fmt.Errorf(
@@ -162,12 +167,53 @@ func addGroupInnerElement(grp *fn.Group, f any) error {
 }
 
 func checkGroup(grp fn.Group) error {
+	if !grp.Virtual {
+		err := checkGroupAddrs(grp)
+		if err != nil {
+			return err
+		}
+	}
 	if len(grp.Irqs) > 0 {
 		return checkIrqGroup(grp)
 	}
 	return nil
 }
 
+// Functionalities of non-virtual group are placed in the group address window,
+// so they cannot have fixed addresses.
+func checkGroupAddrs(grp fn.Group) error {
+	name := ""
+	for _, c := range grp.Configs {
+		if c.Addr != nil {
+			name = c.Name
+		}
+	}
+	for _, m := range grp.Masks {
+		if m.Addr != nil {
+			name = m.Name
+		}
+	}
+	for _, s := range grp.Statics {
+		if s.Addr != nil {
+			name = s.Name
+		}
+	}
+	for _, s := range grp.Statuses {
+		if s.Addr != nil {
+			name = s.Name
+		}
+	}
+
+	if name != "" {
+		return fmt.Errorf(
+			"'%s' in non-virtual group '%s' cannot have 'addr' property, group functionalities are placed in the group address window",
+			name, grp.Name,
+		)
+	}
+
+	return nil
+}
+
 func checkIrqGroup(grp fn.Group) error {
 	// Make sure all irqs within the group have the same out-trigger.
 	irq0 := grp.Irqs[0]
@@ -78,6 +78,9 @@ func Instantiate(packages prs.Packages, mainName string) (*fn.Block, map[string]
 				}
 
 				f := insFunctionality(prsFn)
+				if f == nil {
+					continue
+				}
 
 				if pkgName == "main" && name == mainName {
 					mainBus = f.(*fn.Block)
@@ -91,11 +94,21 @@ func Instantiate(packages prs.Packages, mainName string) (*fn.Block, map[string]
 	return mainBus, pkgs, nil
 }
 
+// insFunctionality instantiates given functionality.
+// It returns nil if the functionality is disabled with the 'enabled' property.
 func insFunctionality(pf prs.Functionality) fn.Functionality {
 	typeChain := resolveToBaseType(pf)
 
+	enabled, err := isEnabled(typeChain)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if !enabled {
+		log.Printf("debug: skipping instantiation of disabled functionality '%s'", pf.Name())
+		return nil
+	}
+
 	var f fn.Functionality
-	var err error
 
 	typ := typeChain[0].Type()
 	switch typ {
@@ -105,12 +118,16 @@ func insFunctionality(pf prs.Functionality) fn.Functionality {
 		f, err = insBlock(typeChain)
 	case "config":
 		f, err = insConfig(typeChain)
+	case "field":
+		f, err = insField(typeChain)
 	case "group":
 		f, err = insGroup(typeChain)
 	case "irq":
 		f, err = insIrq(typeChain)
 	case "mask":
 		f, err = insMask(typeChain)
+	case "memory":
+		f, err = insMemory(typeChain)
 	case "param":
 		f, err = insParam(typeChain)
 	case "proc":
@@ -91,6 +91,8 @@ func applyIrqType(irq *fn.Irq, typ prs.Functionality, diary *irqDiary) error {
 			}
 			diary.enableResetVal = v
 			diary.enableResetValSet = true
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "in-trigger":
 			if diary.inTriggerSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "in-trigger")
@@ -67,12 +67,25 @@ func applyMaskType(mask *fn.Mask, typ prs.Functionality, diary *maskDiary) error
 		}
 
 		switch p.Name {
+		case "access":
+			if mask.AccessMode != "" {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "access")
+			}
+			mask.AccessMode = string(v.(val.Str))
+		case "addr":
+			if mask.Addr != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "addr")
+			}
+			addr := int64(v.(val.Int))
+			mask.Addr = &addr
 		case "atomic":
 			if diary.atomicSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "atomic")
 			}
 			mask.Atomic = bool(v.(val.Bool))
 			diary.atomicSet = true
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "init-value":
 			if diary.initValSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "init-value")
@@ -106,6 +119,9 @@ func applyMaskType(mask *fn.Mask, typ prs.Functionality, diary *maskDiary) error
 }
 
 func fillMaskProps(mask *fn.Mask, diary maskDiary) {
+	if mask.AccessMode == "" {
+		mask.AccessMode = "Read Write"
+	}
 	if !diary.atomicSet {
 		mask.Atomic = true
 	}
@@ -0,0 +1,146 @@
+package ins
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+type memoryDiary struct {
+	byteWriteEnableSet bool
+	readLatencySet     bool
+}
+
+func insMemory(typeChain []prs.Functionality) (*fn.Memory, error) {
+	typeChainStr := fmt.Sprintf("debug: instantiating memory, type chain: %s", typeChain[0].Name())
+	for i := 1; i < len(typeChain); i++ {
+		typeChainStr = fmt.Sprintf("%s -> %s", typeChainStr, typeChain[i].Name())
+	}
+	log.Print(typeChainStr)
+
+	f, err := makeFunctionality(typeChain)
+	if err != nil {
+		return nil, fmt.Errorf("%v", err)
+	}
+	mem := fn.Memory{}
+	mem.Func = f
+
+	diary := memoryDiary{}
+
+	tci := typeChainIter(typeChain)
+	for {
+		typ, ok := tci()
+		if !ok {
+			break
+		}
+		err := applyMemoryType(&mem, typ, &diary)
+		if err != nil {
+			return nil, fmt.Errorf("%v", err)
+		}
+	}
+
+	last := typeChain[len(typeChain)-1]
+	if mem.Size == 0 {
+		return &mem, tok.Error{
+			Msg:  fmt.Sprintf("'%s' of type 'memory' must have 'size' property set", last.Name()),
+			Toks: []tok.Token{last.Tok()},
+		}
+	}
+
+	fillMemoryProps(&mem, diary)
+
+	if mem.ByteWriteEnable {
+		if mem.Access == "Read Only" {
+			return &mem, tok.Error{
+				Msg:  fmt.Sprintf("'%s' has 'byte-write-enable' property set, but its access is \"Read Only\"", last.Name()),
+				Toks: []tok.Token{last.Tok()},
+			}
+		}
+		if mem.Width%8 != 0 {
+			return &mem, tok.Error{
+				Msg: fmt.Sprintf(
+					"'%s' has 'byte-write-enable' property set, but its width %d is not a multiple of 8",
+					last.Name(), mem.Width,
+				),
+				Toks: []tok.Token{last.Tok()},
+			}
+		}
+	}
+
+	return &mem, nil
+}
+
+func applyMemoryType(mem *fn.Memory, typ prs.Functionality, diary *memoryDiary) error {
+	for _, p := range typ.Props() {
+		if err := util.IsValidProperty(p.Name, "memory"); err != nil {
+			return fmt.Errorf(": %v", err)
+		}
+		if err := checkProp(p); err != nil {
+			return fmt.Errorf("%s: %v", p.Loc(), err)
+		}
+
+		v, err := p.Value.Eval()
+		if err != nil {
+			return err
+		}
+
+		switch p.Name {
+		case "access":
+			if mem.Access != "" {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "access")
+			}
+			mem.Access = string(v.(val.Str))
+		case "addr":
+			if mem.Addr != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "addr")
+			}
+			addr := int64(v.(val.Int))
+			mem.Addr = &addr
+		case "byte-write-enable":
+			if diary.byteWriteEnableSet {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "byte-write-enable")
+			}
+			mem.ByteWriteEnable = bool(v.(val.Bool))
+			diary.byteWriteEnableSet = true
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
+		case "read-latency":
+			if diary.readLatencySet {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "read-latency")
+			}
+			mem.ReadLatency = int64(v.(val.Int))
+			diary.readLatencySet = true
+		case "size":
+			if mem.Size != 0 {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "size")
+			}
+			mem.Size = int64(v.(val.Int))
+		case "width":
+			if mem.Width != 0 {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "width")
+			}
+			mem.Width = int64(v.(val.Int))
+		default:
+			panic(fmt.Sprintf("unhandled '%s' property", p.Name))
+		}
+	}
+
+	return nil
+}
+
+func fillMemoryProps(mem *fn.Memory, diary memoryDiary) {
+	if mem.Access == "" {
+		mem.Access = "Read Write"
+	}
+	if !diary.readLatencySet {
+		mem.ReadLatency = 1
+	}
+	if mem.Width == 0 {
+		mem.Width = busWidth
+	}
+}
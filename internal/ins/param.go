@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
@@ -11,8 +12,9 @@ import (
 )
 
 type paramDiary struct {
-	rangeSet bool
-	widthSet bool
+	rangeSet  bool
+	valuesTok tok.Token
+	widthSet  bool
 }
 
 func insParam(typeChain []prs.Functionality) (*fn.Param, error) {
@@ -39,6 +41,15 @@ func insParam(typeChain []prs.Functionality) (*fn.Param, error) {
 
 	fillParamProps(&param, diary)
 
+	if param.Values != nil {
+		if err := checkEnum(param.Values, param.Width, param.Range); err != nil {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("values property: %v", err),
+				Toks: []tok.Token{diary.valuesTok},
+			}
+		}
+	}
+
 	return &param, nil
 }
 
@@ -57,6 +68,8 @@ func applyParamType(param *fn.Param, typ prs.Functionality, diary *paramDiary) e
 		}
 
 		switch p.Name {
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "range":
 			if diary.rangeSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "range")
@@ -84,6 +97,15 @@ func applyParamType(param *fn.Param, typ prs.Functionality, diary *paramDiary) e
 				param.Range = mr
 			}
 			diary.rangeSet = true
+		case "values":
+			if param.Values != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "values")
+			}
+			param.Values, err = makeEnum(p, v)
+			if err != nil {
+				return err
+			}
+			diary.valuesTok = p.ValueTok
 		case "width":
 			if diary.widthSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "width")
@@ -103,10 +125,12 @@ func applyParamType(param *fn.Param, typ prs.Functionality, diary *paramDiary) e
 
 func fillParamProps(param *fn.Param, diary paramDiary) {
 	if !diary.widthSet {
-		if !diary.rangeSet {
-			param.Width = busWidth
-		} else {
+		if diary.rangeSet {
 			param.Width = param.Range.BitWidth()
+		} else if param.Values != nil {
+			param.Width = enumWidth(param.Values)
+		} else {
+			param.Width = busWidth
 		}
 	}
 }
@@ -64,6 +64,8 @@ func applyProcType(p *fn.Proc, typ prs.Functionality, diary *procDiary) error {
 
 			p.Delay = &delay
 			diary.delaySet = true
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		default:
 			panic("should never happen")
 		}
@@ -77,6 +79,9 @@ func applyProcType(p *fn.Proc, typ prs.Functionality, diary *procDiary) error {
 		}
 
 		f := insFunctionality(pe)
+		if f == nil {
+			continue
+		}
 
 		if !util.IsValidInnerType(f.Type(), "proc") {
 			return fmt.Errorf(invalidInnerTypeMsg, f.GetName(), f.Type(), "proc")
@@ -55,6 +55,8 @@ func applyReturnType(ret *fn.Return, typ prs.Functionality, diary *returnDiary)
 		}
 
 		switch p.Name {
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "width":
 			if diary.widthSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "width")
@@ -44,6 +44,14 @@ func insStatic(typeChain []prs.Functionality) (*fn.Static, error) {
 	}
 
 	fillStaticProps(&st, diary)
+
+	if err := checkFields(st.Fields, st.Width); err != nil {
+		last := typeChain[len(typeChain)-1]
+		return nil, tok.Error{
+			Msg:  fmt.Sprintf("'%s' %v", last.Name(), err),
+			Toks: []tok.Token{last.Tok()},
+		}
+	}
 	err = fillStaticValues(&st, diary)
 	if err != nil {
 		last := typeChain[len(typeChain)-1]
@@ -71,6 +79,14 @@ func applyStaticType(st *fn.Static, typ prs.Functionality, diary *staticDiary) e
 		}
 
 		switch p.Name {
+		case "addr":
+			if st.Addr != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "addr")
+			}
+			addr := int64(v.(val.Int))
+			st.Addr = &addr
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "init-value":
 			if diary.initValSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "init-value")
@@ -100,12 +116,17 @@ func applyStaticType(st *fn.Static, typ prs.Functionality, diary *staticDiary) e
 		}
 	}
 
-	return nil
+	return insInnerFields(&st.Fields, typ, "static")
 }
 
 func fillStaticProps(st *fn.Static, diary staticDiary) {
+	fieldsWidth := placeFields(st.Fields)
 	if !diary.widthSet {
-		st.Width = busWidth
+		if st.Fields != nil {
+			st.Width = fieldsWidth
+		} else {
+			st.Width = busWidth
+		}
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/prs"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
@@ -14,6 +15,7 @@ type statusDiary struct {
 	atomicSet  bool
 	readValSet bool
 	readVal    val.Value
+	valuesTok  tok.Token
 	widthSet   bool
 }
 
@@ -41,6 +43,23 @@ func insStatus(typeChain []prs.Functionality) (*fn.Status, error) {
 
 	fillStatusProps(&st, diary)
 
+	if err := checkFields(st.Fields, st.Width); err != nil {
+		last := typeChain[len(typeChain)-1]
+		return nil, tok.Error{
+			Msg:  fmt.Sprintf("'%s' %v", last.Name(), err),
+			Toks: []tok.Token{last.Tok()},
+		}
+	}
+
+	if st.Values != nil {
+		if err := checkEnum(st.Values, st.Width, nil); err != nil {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("values property: %v", err),
+				Toks: []tok.Token{diary.valuesTok},
+			}
+		}
+	}
+
 	if diary.readValSet {
 		val, err := processValue(diary.readVal, st.Width)
 		if err != nil {
@@ -67,18 +86,35 @@ func applyStatusType(st *fn.Status, typ prs.Functionality, diary *statusDiary) e
 		}
 
 		switch p.Name {
+		case "addr":
+			if st.Addr != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "addr")
+			}
+			addr := int64(v.(val.Int))
+			st.Addr = &addr
 		case "atomic":
 			if diary.atomicSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "atomic")
 			}
 			st.Atomic = bool(v.(val.Bool))
 			diary.atomicSet = true
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		case "read-value":
 			if diary.readValSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "read-value")
 			}
 			diary.readVal = v
 			diary.readValSet = true
+		case "values":
+			if st.Values != nil {
+				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "values")
+			}
+			st.Values, err = makeEnum(p, v)
+			if err != nil {
+				return err
+			}
+			diary.valuesTok = p.ValueTok
 		case "width":
 			if diary.widthSet {
 				return fmt.Errorf(propAlreadySetMsg, p.Loc(), "width")
@@ -90,14 +126,21 @@ func applyStatusType(st *fn.Status, typ prs.Functionality, diary *statusDiary) e
 		}
 	}
 
-	return nil
+	return insInnerFields(&st.Fields, typ, "status")
 }
 
 func fillStatusProps(st *fn.Status, diary statusDiary) {
 	if !diary.atomicSet {
 		st.Atomic = true
 	}
+	fieldsWidth := placeFields(st.Fields)
 	if !diary.widthSet {
-		st.Width = busWidth
+		if st.Values != nil {
+			st.Width = enumWidth(st.Values)
+		} else if st.Fields != nil {
+			st.Width = fieldsWidth
+		} else {
+			st.Width = busWidth
+		}
 	}
 }
@@ -65,6 +65,8 @@ func applyStreamType(strm *fn.Stream, typ prs.Functionality, diary *streamDiary)
 
 			strm.Delay = &delay
 			diary.delaySet = true
+		case "enabled":
+			// Handled before the instantiation, see isEnabled.
 		default:
 			panic("should never happen")
 		}
@@ -78,6 +80,9 @@ func applyStreamType(strm *fn.Stream, typ prs.Functionality, diary *streamDiary)
 		}
 
 		f := insFunctionality(pe)
+		if f == nil {
+			continue
+		}
 
 		if !util.IsValidInnerType(f.Type(), "stream") {
 			return fmt.Errorf(invalidInnerTypeMsg, f.GetName(), f.Type(), "stream")
@@ -0,0 +1,281 @@
+// Package lock implements the address lock file.
+//
+// The lock file records addresses assigned during the registerification.
+// When the lock file is read on the next compilation, the registerification keeps
+// locked addresses of unchanged functionalities, subblocks, blackboxes and memories,
+// so that adding a functionality does not shift addresses of the already existing ones.
+package lock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/block"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// Func is a locked functionality.
+//
+// Addrs are strobe addresses, for example irq clear address or proc call and exit addresses.
+// A nil address indicates that the functionality has no such strobe.
+type Func struct {
+	Type     string
+	Accesses []types.Access
+	Addrs    []*int64
+}
+
+// Regs returns registers occupied by the functionality.
+func (f Func) Regs() []types.SingleRange {
+	regs := []types.SingleRange{}
+	for _, a := range f.Accesses {
+		regs = append(regs, types.SingleRange{Start: a.StartAddr, End: a.EndAddr})
+	}
+	for _, a := range f.Addrs {
+		if a != nil {
+			regs = append(regs, types.SingleRange{Start: *a, End: *a})
+		}
+	}
+	return regs
+}
+
+// Lock is the content of the lock file.
+//
+// Keys are hierarchical names separated with '.', for example "main.blk.cfg".
+// Address spaces of subblocks, blackboxes and memories are global.
+type Lock struct {
+	AddrSpaces map[string]types.SingleRange
+	Funcs      map[string]Func
+}
+
+// Read reads the lock file.
+// If the file does not exist, it returns nil lock and nil error.
+func Read(path string) (*Lock, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read lock file: %v", err)
+	}
+
+	lck := Lock{}
+	err = json.Unmarshal(bytes, &lck)
+	if err != nil {
+		return nil, fmt.Errorf("read lock file %s: %v", path, err)
+	}
+
+	return &lck, nil
+}
+
+// Write writes the lock file.
+func (l *Lock) Write(path string) error {
+	bytes, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("write lock file: %v", err)
+	}
+
+	err = os.WriteFile(path, append(bytes, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("write lock file: %v", err)
+	}
+
+	return nil
+}
+
+// Make makes lock for the registerified bus.
+func Make(bus *fn.Block) Lock {
+	lck := Lock{
+		AddrSpaces: map[string]types.SingleRange{},
+		Funcs:      map[string]Func{},
+	}
+	lck.addBlock(bus, bus.Name)
+	return lck
+}
+
+func (l *Lock) addBlock(blk *fn.Block, path string) {
+	l.AddrSpaces[path] = blk.AddrSpace
+
+	for _, f := range block.OwnFunctionalities(blk) {
+		l.Funcs[path+"."+f.GetName()] = Extract(f)
+	}
+
+	for _, bb := range blk.Blackboxes {
+		l.AddrSpaces[path+"."+bb.Name] = bb.AddrSpace
+	}
+	for _, mem := range blk.Memories {
+		l.AddrSpaces[path+"."+mem.Name] = mem.AddrSpace
+	}
+	for _, sb := range blk.Subblocks {
+		l.addBlock(sb, path+"."+sb.Name)
+	}
+}
+
+// Extract extracts lock of the registerified functionality.
+func Extract(f fn.Functionality) Func {
+	lf := Func{Type: f.Type()}
+
+	switch f := f.(type) {
+	case *fn.Config:
+		lf.Accesses = []types.Access{f.Access}
+	case *fn.Irq:
+		lf.Accesses = []types.Access{f.Access}
+		if f.AddEnable {
+			lf.Accesses = append(lf.Accesses, f.EnableAccess)
+		}
+		lf.Addrs = []*int64{f.ClearAddr}
+	case *fn.Mask:
+		lf.Accesses = []types.Access{f.Access}
+	case *fn.Proc:
+		for _, p := range f.Params {
+			lf.Accesses = append(lf.Accesses, p.Access)
+		}
+		for _, r := range f.Returns {
+			lf.Accesses = append(lf.Accesses, r.Access)
+		}
+		lf.Addrs = []*int64{f.CallAddr, f.ExitAddr}
+	case *fn.Static:
+		lf.Accesses = []types.Access{f.Access}
+	case *fn.Status:
+		lf.Accesses = []types.Access{f.Access}
+	case *fn.Stream:
+		for _, p := range f.Params {
+			lf.Accesses = append(lf.Accesses, p.Access)
+		}
+		for _, r := range f.Returns {
+			lf.Accesses = append(lf.Accesses, r.Access)
+		}
+		stbAddr := f.StbAddr
+		lf.Addrs = []*int64{&stbAddr}
+	default:
+		panic("should never happen")
+	}
+
+	return lf
+}
+
+// Check checks whether the locked functionality is compatible with the functionality.
+// The lock is compatible if the functionality type, and the count and width
+// of all accessed items are unchanged, so the locked accesses can be reused.
+func Check(f fn.Functionality, lf Func) error {
+	if lf.Type != f.Type() {
+		return fmt.Errorf("type changed from %s to %s", lf.Type, f.Type())
+	}
+
+	// Accessed items.
+	type item struct {
+		isArray      bool
+		count, width int64
+	}
+	items := []item{}
+	// Presence of strobe addresses.
+	addrs := []bool{}
+
+	switch f := f.(type) {
+	case *fn.Config:
+		items = append(items, item{f.IsArray, f.Count, f.Width})
+	case *fn.Irq:
+		items = append(items, item{f.IsArray, f.Count, 1})
+		if f.AddEnable {
+			items = append(items, item{f.IsArray, f.Count, 1})
+		}
+		addrs = append(addrs, f.Clear == "Explicit")
+	case *fn.Mask:
+		items = append(items, item{f.IsArray, f.Count, f.Width})
+	case *fn.Proc:
+		for _, p := range f.Params {
+			items = append(items, item{p.IsArray, p.Count, p.Width})
+		}
+		for _, r := range f.Returns {
+			items = append(items, item{r.IsArray, r.Count, r.Width})
+		}
+		params := len(f.Params) > 0
+		returns := len(f.Returns) > 0
+		addrs = append(
+			addrs,
+			params || !returns || f.Delay != nil,
+			returns || f.Delay != nil,
+		)
+	case *fn.Static:
+		items = append(items, item{f.IsArray, f.Count, f.Width})
+	case *fn.Status:
+		items = append(items, item{f.IsArray, f.Count, f.Width})
+	case *fn.Stream:
+		for _, p := range f.Params {
+			items = append(items, item{p.IsArray, p.Count, p.Width})
+		}
+		for _, r := range f.Returns {
+			items = append(items, item{r.IsArray, r.Count, r.Width})
+		}
+		addrs = append(addrs, true)
+	default:
+		panic("should never happen")
+	}
+
+	if len(items) != len(lf.Accesses) || len(addrs) != len(lf.Addrs) {
+		return fmt.Errorf("number of accessed items changed")
+	}
+	for i, it := range items {
+		acs := lf.Accesses[i]
+		if strings.HasPrefix(acs.Type, "Array") != it.isArray {
+			return fmt.Errorf("array changed to single item or vice versa")
+		}
+		if acs.ItemCount != it.count || acs.ItemWidth != it.width {
+			return fmt.Errorf("count or width changed")
+		}
+	}
+	for i, a := range addrs {
+		if a != (lf.Addrs[i] != nil) {
+			return fmt.Errorf("strobes changed")
+		}
+	}
+
+	return nil
+}
+
+// Apply applies locked accesses and strobe addresses to the functionality.
+// The functionality is not modified if the lock is not compatible.
+func Apply(f fn.Functionality, lf Func) error {
+	if err := Check(f, lf); err != nil {
+		return err
+	}
+
+	switch f := f.(type) {
+	case *fn.Config:
+		f.Access = lf.Accesses[0]
+	case *fn.Irq:
+		f.Access = lf.Accesses[0]
+		if f.AddEnable {
+			f.EnableAccess = lf.Accesses[1]
+		}
+		f.ClearAddr = lf.Addrs[0]
+	case *fn.Mask:
+		f.Access = lf.Accesses[0]
+	case *fn.Proc:
+		for i, p := range f.Params {
+			p.Access = lf.Accesses[i]
+		}
+		for i, r := range f.Returns {
+			r.Access = lf.Accesses[len(f.Params)+i]
+		}
+		f.CallAddr = lf.Addrs[0]
+		f.ExitAddr = lf.Addrs[1]
+	case *fn.Static:
+		f.Access = lf.Accesses[0]
+	case *fn.Status:
+		f.Access = lf.Accesses[0]
+	case *fn.Stream:
+		for i, p := range f.Params {
+			p.Access = lf.Accesses[i]
+		}
+		for i, r := range f.Returns {
+			r.Access = lf.Accesses[len(f.Params)+i]
+		}
+		f.StbAddr = *lf.Addrs[0]
+	}
+
+	return nil
+}
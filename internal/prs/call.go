@@ -3,36 +3,117 @@ package prs
 import (
 	"fmt"
 	"math"
+	"math/bits"
+	"strconv"
+	"strings"
 
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/tok"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/val"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
 )
 
+// Minimum and maximum number of arguments of built-in functions.
+// Maximum equal -1 means that the number of arguments is not limited.
+var funcArgCount = map[string]struct{ min, max int }{
+	"abs":    {1, 1},
+	"bitstr": {2, 2},
+	"bool":   {1, 1},
+	"ceil":   {1, 1},
+	"clog2":  {1, 1},
+	"float":  {1, 1},
+	"floor":  {1, 1},
+	"format": {1, -1},
+	"int":    {1, 1},
+	"len":    {1, 1},
+	"log2":   {1, 1},
+	"log10":  {1, 1},
+	"max":    {1, -1},
+	"min":    {1, -1},
+	"pow":    {2, 2},
+	"range":  {1, 3},
+	"str":    {1, 1},
+	"sum":    {1, 1},
+}
+
 // assertCall asserts that function name for given call is supported,
 // and that the number of arguments for given call is valid.
 func assertCall(c Call) error {
-	validFuncNames := map[string]bool{
-		"bool": true, "ceil": true, "floor": true, "log2": true, "log10": true,
-	}
-
-	validArgCount := map[string]int{
-		"bool": 1, "ceil": 1, "floor": 1, "log2": 1, "log10": 1,
-	}
-
-	if ok := validFuncNames[c.funcName]; !ok {
+	count, ok := funcArgCount[c.funcName]
+	if !ok {
 		return fmt.Errorf("unknown function '%s'", c.funcName)
 	}
 
-	count := validArgCount[c.funcName]
-	if len(c.args) != count {
+	n := len(c.args)
+	if count.min == count.max && n != count.min {
 		return fmt.Errorf(
 			"function '%s' takes %d arguments, but %d were provided",
-			c.funcName, count, len(c.args),
+			c.funcName, count.min, n,
+		)
+	} else if count.max == -1 && n < count.min {
+		return fmt.Errorf(
+			"function '%s' takes at least %d arguments, but %d were provided",
+			c.funcName, count.min, n,
+		)
+	} else if n < count.min || (count.max != -1 && n > count.max) {
+		return fmt.Errorf(
+			"function '%s' takes from %d to %d arguments, but %d were provided",
+			c.funcName, count.min, count.max, n,
 		)
 	}
 
 	return nil
 }
 
+// evalArgs evaluates all arguments of the call.
+func evalArgs(c Call) ([]val.Value, error) {
+	args := make([]val.Value, 0, len(c.args))
+	for i, a := range c.args {
+		v, err := a.Eval()
+		if err != nil {
+			// Errors with tokens already point at the invalid argument.
+			if _, ok := err.(tok.Error); ok {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s argument %d evaluation: %v", c.funcName, i, err)
+		}
+		args = append(args, v)
+	}
+	return args, nil
+}
+
+// argError returns error pointing at the call argument with index i.
+func argError(c Call, i int, msg string, args ...any) error {
+	return tok.Error{
+		Msg:  fmt.Sprintf(msg, args...),
+		Toks: []tok.Token{c.ast.Args[i].Tok()},
+	}
+}
+
+func callError(c Call, msg string, args ...any) error {
+	return tok.Error{
+		Msg:  fmt.Sprintf(msg, args...),
+		Toks: []tok.Token{c.ast.Name},
+	}
+}
+
+func invalidArgType(c Call, i int, arg val.Value, want string) error {
+	return argError(
+		c, i, "invalid argument type '%s' for %s function, expected %s", arg.Type(), c.funcName, want,
+	)
+}
+
+// toFloat converts numeric value to float64.
+// The second return value is false if the value is not numeric.
+func toFloat(v val.Value) (float64, bool) {
+	switch v := v.(type) {
+	case val.Int:
+		return float64(v), true
+	case val.Float:
+		return float64(v), true
+	}
+	return 0, false
+}
+
 func evalBool(c Call) (val.Value, error) {
 	arg, err := c.args[0].Eval()
 	if err != nil {
@@ -145,3 +226,432 @@ func evalLog10(c Call) (val.Value, error) {
 
 	return val.Float(r), nil
 }
+
+func evalAbs(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	switch arg := args[0].(type) {
+	case val.Int:
+		if arg < 0 {
+			return -arg, nil
+		}
+		return arg, nil
+	case val.Float:
+		return val.Float(math.Abs(float64(arg))), nil
+	}
+
+	return nil, invalidArgType(c, 0, args[0], "integer or float")
+}
+
+// evalBitStr evaluates the bitstr function.
+// It converts an integer to the bit string with the given width, using U2 encoding for negative values.
+func evalBitStr(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	width, ok := args[1].(val.Int)
+	if !ok {
+		return nil, invalidArgType(c, 1, args[1], "integer")
+	}
+	if width < 1 || width > 64 {
+		return nil, argError(c, 1, "bitstr function width must be in range 1:64, current value %d", width)
+	}
+
+	switch arg := args[0].(type) {
+	case val.BitStr:
+		if arg.BitWidth() > int64(width) {
+			return nil, argError(
+				c, 0, "bit string width %d is greater than bitstr function width %d", arg.BitWidth(), width,
+			)
+		}
+		return val.MakeBitStr(string(types.MakeBitStr(arg).Extend(int64(width))))
+	case val.Int:
+		bs, err := val.BitStrFromInt(arg, int64(width))
+		if err != nil {
+			return nil, argError(c, 0, "%v", err)
+		}
+		return bs, nil
+	}
+
+	return nil, invalidArgType(c, 0, args[0], "integer or bit string")
+}
+
+func evalClog2(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	arg, ok := args[0].(val.Int)
+	if !ok {
+		return nil, invalidArgType(c, 0, args[0], "integer")
+	}
+	if arg < 1 {
+		return nil, argError(c, 0, "clog2 function argument must be positive, current value %d", arg)
+	}
+
+	return val.Int(bits.Len64(uint64(arg - 1))), nil
+}
+
+func evalFloat(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	switch arg := args[0].(type) {
+	case val.Int:
+		return val.Float(arg), nil
+	case val.Float:
+		return arg, nil
+	case val.Str:
+		f, err := strconv.ParseFloat(string(arg), 64)
+		if err != nil {
+			return nil, argError(c, 0, "cannot convert string %q to float", arg)
+		}
+		return val.Float(f), nil
+	}
+
+	return nil, invalidArgType(c, 0, args[0], "integer, float or string")
+}
+
+func evalInt(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	switch arg := args[0].(type) {
+	case val.Bool:
+		if arg {
+			return val.Int(1), nil
+		}
+		return val.Int(0), nil
+	case val.Int:
+		return arg, nil
+	case val.Float:
+		return val.Int(int64(arg)), nil
+	case val.BitStr:
+		i, err := arg.Int()
+		if err != nil {
+			return nil, argError(c, 0, "%v", err)
+		}
+		return i, nil
+	case val.Str:
+		i, err := strconv.ParseInt(string(arg), 0, 64)
+		if err != nil {
+			return nil, argError(c, 0, "cannot convert string %q to integer", arg)
+		}
+		return val.Int(i), nil
+	}
+
+	return nil, invalidArgType(c, 0, args[0], "bit string, bool, integer, float or string")
+}
+
+func evalLen(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	switch arg := args[0].(type) {
+	case val.List:
+		return val.Int(len(arg)), nil
+	case val.Str:
+		return val.Int(len([]rune(arg))), nil
+	}
+
+	return nil, invalidArgType(c, 0, args[0], "list or string")
+}
+
+// evalMinMax evaluates the min or max function.
+// The function accepts either a single list or a variable number of numeric arguments.
+// The selected argument keeps its type.
+func evalMinMax(c Call, better func(x, y float64) bool) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	items := args
+	fromList := false
+	if l, ok := args[0].(val.List); ok && len(args) == 1 {
+		if len(l) == 0 {
+			return nil, argError(c, 0, "%s function argument is an empty list", c.funcName)
+		}
+		items = l
+		fromList = true
+	}
+
+	var r val.Value
+	rf := 0.0
+	for i, item := range items {
+		f, ok := toFloat(item)
+		if !ok {
+			if fromList {
+				return nil, argError(
+					c, 0, "%s function list value with index %d must be of type integer or float, current type %s",
+					c.funcName, i, item.Type(),
+				)
+			}
+			return nil, invalidArgType(c, i, item, "integer or float")
+		}
+		if r == nil || better(f, rf) {
+			r = item
+			rf = f
+		}
+	}
+
+	return r, nil
+}
+
+func evalMax(c Call) (val.Value, error) {
+	return evalMinMax(c, func(x, y float64) bool { return x > y })
+}
+
+func evalMin(c Call) (val.Value, error) {
+	return evalMinMax(c, func(x, y float64) bool { return x < y })
+}
+
+func evalPow(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	x, ok := toFloat(args[0])
+	if !ok {
+		return nil, invalidArgType(c, 0, args[0], "integer or float")
+	}
+	y, ok := toFloat(args[1])
+	if !ok {
+		return nil, invalidArgType(c, 1, args[1], "integer or float")
+	}
+
+	// Integer power with natural exponent is calculated exactly.
+	if b, ok := args[0].(val.Int); ok {
+		if e, ok := args[1].(val.Int); ok && e >= 0 {
+			r, ok := powInt(int64(b), int64(e))
+			if !ok {
+				return nil, callError(c, "pow function result overflows integer type, base %d, exponent %d", b, e)
+			}
+			return val.Int(r), nil
+		}
+	}
+
+	return val.Float(math.Pow(x, y)), nil
+}
+
+// powInt returns b to the power of e using exponentiation by squaring.
+// The second return value is false if the result overflows int64.
+func powInt(b, e int64) (int64, bool) {
+	r := int64(1)
+	for e > 0 {
+		var ok bool
+		if e&1 == 1 {
+			if r, ok = mulInt(r, b); !ok {
+				return 0, false
+			}
+		}
+		e >>= 1
+		if e > 0 {
+			if b, ok = mulInt(b, b); !ok {
+				return 0, false
+			}
+		}
+	}
+	return r, true
+}
+
+// mulInt returns x * y.
+// The second return value is false if the result overflows int64.
+func mulInt(x, y int64) (int64, bool) {
+	if x == 0 || y == 0 {
+		return 0, true
+	}
+	if (x == -1 && y == math.MinInt64) || (y == -1 && x == math.MinInt64) {
+		return 0, false
+	}
+	r := x * y
+	if r/y != x {
+		return 0, false
+	}
+	return r, true
+}
+
+// Maximum number of items of the list returned by the range function.
+const maxRangeLen = 1 << 20
+
+// evalRange evaluates the range function.
+// Similarly to Python, it returns the list of integers from start (inclusive) to stop (exclusive).
+func evalRange(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	ints := make([]int64, len(args))
+	for i, a := range args {
+		v, ok := a.(val.Int)
+		if !ok {
+			return nil, invalidArgType(c, i, a, "integer")
+		}
+		ints[i] = int64(v)
+	}
+
+	start, stop, step := int64(0), int64(0), int64(1)
+	switch len(ints) {
+	case 1:
+		stop = ints[0]
+	case 2:
+		start, stop = ints[0], ints[1]
+	case 3:
+		start, stop, step = ints[0], ints[1], ints[2]
+	}
+	if step == 0 {
+		return nil, argError(c, 2, "range function step must not be zero")
+	}
+
+	l := val.List{}
+	for i := start; (step > 0 && i < stop) || (step < 0 && i > stop); i += step {
+		if len(l) == maxRangeLen {
+			return nil, callError(c, "range function result has more than %d items", maxRangeLen)
+		}
+		l = append(l, val.Int(i))
+		// Stop before the next item overflows the integer type.
+		if (step > 0 && i > math.MaxInt64-step) || (step < 0 && i < math.MinInt64-step) {
+			break
+		}
+	}
+
+	return l, nil
+}
+
+func evalStr(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := formatValue(args[0])
+	if !ok {
+		return nil, invalidArgType(c, 0, args[0], "bit string, bool, integer, float, string or time")
+	}
+
+	return val.Str(s), nil
+}
+
+// formatValue returns string representation of the value.
+// The second return value is false if the value cannot be converted to string.
+func formatValue(v val.Value) (string, bool) {
+	switch v := v.(type) {
+	case val.BitStr:
+		return string(v), true
+	case val.Bool:
+		return strconv.FormatBool(bool(v)), true
+	case val.Int:
+		return strconv.FormatInt(int64(v), 10), true
+	case val.Float:
+		return strconv.FormatFloat(float64(v), 'g', -1, 64), true
+	case val.Str:
+		return string(v), true
+	case val.Time:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// evalFormat evaluates the format function.
+// Each "{}" in the format string is replaced with the string representation of the next argument.
+// Literal braces are written as "{{" and "}}".
+func evalFormat(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	f, ok := args[0].(val.Str)
+	if !ok {
+		return nil, invalidArgType(c, 0, args[0], "string")
+	}
+
+	b := strings.Builder{}
+	argIdx := 1
+	for i := 0; i < len(f); i++ {
+		switch {
+		case strings.HasPrefix(string(f[i:]), "{{"):
+			b.WriteByte('{')
+			i++
+		case strings.HasPrefix(string(f[i:]), "}}"):
+			b.WriteByte('}')
+			i++
+		case strings.HasPrefix(string(f[i:]), "{}"):
+			if argIdx == len(args) {
+				return nil, argError(
+					c, 0, "format string has more placeholders than provided arguments (%d)", len(args)-1,
+				)
+			}
+			s, ok := formatValue(args[argIdx])
+			if !ok {
+				return nil, invalidArgType(c, argIdx, args[argIdx], "bit string, bool, integer, float, string or time")
+			}
+			b.WriteString(s)
+			argIdx++
+			i++
+		case f[i] == '{' || f[i] == '}':
+			return nil, argError(c, 0, "unmatched '%c' in format string at index %d", f[i], i)
+		default:
+			b.WriteByte(f[i])
+		}
+	}
+	if argIdx != len(args) {
+		return nil, argError(
+			c, argIdx, "format string has fewer placeholders (%d) than provided arguments (%d)",
+			argIdx-1, len(args)-1,
+		)
+	}
+
+	return val.Str(b.String()), nil
+}
+
+// evalSum evaluates the sum function.
+// The result is of integer type if all list values are integers.
+func evalSum(c Call) (val.Value, error) {
+	args, err := evalArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	l, ok := args[0].(val.List)
+	if !ok {
+		return nil, invalidArgType(c, 0, args[0], "list")
+	}
+
+	isum := val.Int(0)
+	fsum := 0.0
+	isFloat := false
+	for i, item := range l {
+		switch item := item.(type) {
+		case val.Int:
+			isum += item
+		case val.Float:
+			fsum += float64(item)
+			isFloat = true
+		default:
+			return nil, argError(
+				c, 0, "sum function list value with index %d must be of type integer or float, current type %s",
+				i, item.Type(),
+			)
+		}
+	}
+
+	if isFloat {
+		return val.Float(float64(isum) + fsum), nil
+	}
+	return isum, nil
+}
@@ -1,6 +1,7 @@
 package prs
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"strconv"
@@ -27,8 +28,12 @@ func MakeExpr(astExpr ast.Expr, src []byte, s Scope) (Expr, error) {
 		expr, err = MakeBitString(e, src)
 	case ast.Call:
 		expr, err = MakeCall(e, src, s)
+	case ast.CondExpr:
+		expr, err = MakeCondExpr(e, src, s)
 	case ast.Ident:
 		expr = MakeDeclaredIdentifier(e, src, s)
+	case ast.IndexExpr:
+		expr, err = MakeIndexExpr(e, src, s)
 	case ast.QualIdent:
 		expr = MakeQualifiedIdentifier(e, src, s)
 	case ast.Int:
@@ -45,6 +50,8 @@ func MakeExpr(astExpr ast.Expr, src []byte, s Scope) (Expr, error) {
 		expr, err = MakeTime(e, src, s)
 	case ast.UnaryExpr:
 		expr, err = MakeUnaryExpr(e, src, s)
+	case ast.ParenExpr:
+		expr, err = MakeExpr(e.X, src, s)
 	case nil:
 		return nil, nil
 	default:
@@ -73,6 +80,36 @@ func (be BinaryExpr) Eval() (val.Value, error) {
 	}
 	op := be.op // Operator
 
+	if _, ok := op.(tok.In); ok {
+		l, ok := y.(val.List)
+		if !ok {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("right operand of 'in' operator must be of type list, current type %s", y.Type()),
+				Toks: []tok.Token{be.ast.Y.Tok()},
+			}
+		}
+		for _, item := range l {
+			if val.Equal(x, item) {
+				return val.Bool(true), nil
+			}
+		}
+		return val.Bool(false), nil
+	}
+
+	switch op.(type) {
+	case tok.Eq, tok.Neq, tok.Less, tok.LessEq, tok.Greater, tok.GreaterEq:
+		if v := compare(op, x, y); v != nil {
+			return v, nil
+		}
+		return nil, tok.Error{
+			Msg: fmt.Sprintf(
+				"cannot compare operands with %s operator, left operand type %s, right operand type %s",
+				op.Name(), x.Type(), y.Type(),
+			),
+			Toks: []tok.Token{op},
+		}
+	}
+
 	var v val.Value
 
 	switch x := x.(type) {
@@ -92,6 +129,8 @@ func (be BinaryExpr) Eval() (val.Value, error) {
 			switch y := y.(type) {
 			case val.Int:
 				v = x * y
+			case val.BitStr:
+				return evalBitStrRepeat(be, y, x)
 			}
 		case tok.Div:
 			switch y := y.(type) {
@@ -155,6 +194,71 @@ func (be BinaryExpr) Eval() (val.Value, error) {
 				}
 			}
 		}
+	case val.BitStr:
+		switch op.(type) {
+		case tok.Add:
+			switch y := y.(type) {
+			case val.BitStr:
+				v = val.Concat(x, y)
+			}
+		case tok.Mul:
+			switch y := y.(type) {
+			case val.Int:
+				return evalBitStrRepeat(be, x, y)
+			}
+		case tok.BitAnd, tok.BitOr, tok.Xor:
+			switch y := y.(type) {
+			case val.BitStr:
+				if x.BitWidth() != y.BitWidth() {
+					return nil, tok.Error{
+						Msg: fmt.Sprintf(
+							"bit string operands of %s operator must have equal widths, left width %d, right width %d",
+							op.Name(), x.BitWidth(), y.BitWidth(),
+						),
+						Toks: []tok.Token{be.ast.Tok()},
+					}
+				}
+				switch op.(type) {
+				case tok.BitAnd:
+					v = val.And(x, y)
+				case tok.BitOr:
+					v = val.Or(x, y)
+				case tok.Xor:
+					v = val.Xor(x, y)
+				}
+			}
+		}
+	case val.Str:
+		switch op.(type) {
+		case tok.Add:
+			switch y := y.(type) {
+			case val.Str:
+				v = x + y
+			}
+		}
+	case val.Bool:
+		switch op.(type) {
+		case tok.And:
+			switch y := y.(type) {
+			case val.Bool:
+				v = x && y
+			}
+		case tok.Or:
+			switch y := y.(type) {
+			case val.Bool:
+				v = x || y
+			}
+		}
+	case val.List:
+		switch op.(type) {
+		case tok.Add:
+			switch y := y.(type) {
+			case val.List:
+				l := make(val.List, 0, len(x)+len(y))
+				l = append(l, x...)
+				v = append(l, y...)
+			}
+		}
 	case val.Range:
 		switch op.(type) {
 		case tok.Colon:
@@ -178,6 +282,94 @@ func (be BinaryExpr) Eval() (val.Value, error) {
 	}
 }
 
+// compare evaluates comparison operator for scalar operands.
+// Integers and floats can be compared with each other.
+// Bools can only be compared for equality.
+// The function returns nil if operands cannot be compared.
+func compare(op tok.Operator, x, y val.Value) val.Value {
+	var c int
+	switch x := x.(type) {
+	case val.Int:
+		switch y := y.(type) {
+		case val.Int:
+			c = cmp.Compare(x, y)
+		case val.Float:
+			c = cmp.Compare(float64(x), float64(y))
+		default:
+			return nil
+		}
+	case val.Float:
+		switch y := y.(type) {
+		case val.Int:
+			c = cmp.Compare(float64(x), float64(y))
+		case val.Float:
+			c = cmp.Compare(x, y)
+		default:
+			return nil
+		}
+	case val.Str:
+		y, ok := y.(val.Str)
+		if !ok {
+			return nil
+		}
+		c = cmp.Compare(x, y)
+	case val.Bool:
+		y, ok := y.(val.Bool)
+		if !ok {
+			return nil
+		}
+		switch op.(type) {
+		case tok.Eq:
+			return val.Bool(x == y)
+		case tok.Neq:
+			return val.Bool(x != y)
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	switch op.(type) {
+	case tok.Eq:
+		return val.Bool(c == 0)
+	case tok.Neq:
+		return val.Bool(c != 0)
+	case tok.Less:
+		return val.Bool(c < 0)
+	case tok.LessEq:
+		return val.Bool(c <= 0)
+	case tok.Greater:
+		return val.Bool(c > 0)
+	case tok.GreaterEq:
+		return val.Bool(c >= 0)
+	}
+
+	return nil
+}
+
+// Maximum width of the bit string resulting from the bit string replication.
+const maxBitStrRepeatWidth = 1 << 20
+
+// evalBitStrRepeat evaluates bit string replication.
+func evalBitStrRepeat(be BinaryExpr, bs val.BitStr, n val.Int) (val.Value, error) {
+	if n < 1 {
+		return nil, tok.Error{
+			Msg:  fmt.Sprintf("bit string replication count must be positive, current value %d", n),
+			Toks: []tok.Token{be.ast.Tok()},
+		}
+	}
+	if w := bs.BitWidth(); w > 0 && int64(n) > maxBitStrRepeatWidth/w {
+		return nil, tok.Error{
+			Msg: fmt.Sprintf(
+				"bit string replication result is wider than %d bits, bit string width %d, count %d",
+				maxBitStrRepeatWidth, bs.BitWidth(), n,
+			),
+			Toks: []tok.Token{be.ast.Tok()},
+		}
+	}
+	return bs.Repeat(int64(n)), nil
+}
+
 func MakeBinaryExpr(be ast.BinaryExpr, src []byte, s Scope) (BinaryExpr, error) {
 	x, err := MakeExpr(be.X, src, s)
 	if err != nil {
@@ -210,29 +402,57 @@ func MakeBitString(e ast.BitString, src []byte) (BitString, error) {
 }
 
 type Call struct {
+	ast ast.Call
+
 	funcName string
 	args     []Expr
 }
 
 func (c Call) Eval() (val.Value, error) {
 	switch c.funcName {
+	case "abs":
+		return evalAbs(c)
+	case "bitstr":
+		return evalBitStr(c)
 	case "bool":
 		return evalBool(c)
 	case "ceil":
 		return evalCeil(c)
+	case "clog2":
+		return evalClog2(c)
+	case "float":
+		return evalFloat(c)
 	case "floor":
 		return evalFloor(c)
+	case "format":
+		return evalFormat(c)
+	case "int":
+		return evalInt(c)
+	case "len":
+		return evalLen(c)
 	case "log2":
 		return evalLog2(c)
 	case "log10":
 		return evalLog10(c)
+	case "max":
+		return evalMax(c)
+	case "min":
+		return evalMin(c)
+	case "pow":
+		return evalPow(c)
+	case "range":
+		return evalRange(c)
+	case "str":
+		return evalStr(c)
+	case "sum":
+		return evalSum(c)
 	}
 
 	panic("should never happen")
 }
 
 func MakeCall(e ast.Call, src []byte, s Scope) (Call, error) {
-	c := Call{funcName: tok.Text(e.Name, src), args: []Expr{}}
+	c := Call{ast: e, funcName: tok.Text(e.Name, src), args: []Expr{}}
 
 	for i, a := range e.Args {
 		expr, err := MakeExpr(a, src, s)
@@ -250,6 +470,160 @@ func MakeCall(e ast.Call, src []byte, s Scope) (Call, error) {
 	return c, nil
 }
 
+// CondExpr is the conditional expression.
+// Only the selected branch is evaluated.
+type CondExpr struct {
+	ast ast.CondExpr
+
+	c Expr
+	x Expr
+	y Expr
+}
+
+func (ce CondExpr) Eval() (val.Value, error) {
+	c, err := ce.c.Eval()
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := c.(val.Bool)
+	if !ok {
+		return nil, tok.Error{
+			Msg:  fmt.Sprintf("condition of conditional expression must be of type bool, current type %s", c.Type()),
+			Toks: []tok.Token{ce.ast.C.Tok()},
+		}
+	}
+
+	if b {
+		return ce.x.Eval()
+	}
+	return ce.y.Eval()
+}
+
+func MakeCondExpr(ce ast.CondExpr, src []byte, s Scope) (CondExpr, error) {
+	c, err := MakeExpr(ce.C, src, s)
+	if err != nil {
+		return CondExpr{}, fmt.Errorf("make conditional expression: condition: %v", err)
+	}
+
+	x, err := MakeExpr(ce.X, src, s)
+	if err != nil {
+		return CondExpr{}, fmt.Errorf("make conditional expression: true branch: %v", err)
+	}
+
+	y, err := MakeExpr(ce.Y, src, s)
+	if err != nil {
+		return CondExpr{}, fmt.Errorf("make conditional expression: false branch: %v", err)
+	}
+
+	return CondExpr{ast: ce, c: c, x: x, y: y}, nil
+}
+
+// IndexExpr is the index or slice expression.
+// The slice is selected with the range, and both range bounds are inclusive,
+// for example, [1, 2, 3, 4][1:2] is [2, 3].
+// Bit strings are indexed from the least significant bit, for example, b"1100"[2:3] is b"11".
+type IndexExpr struct {
+	ast ast.IndexExpr
+
+	x   Expr
+	idx Expr
+}
+
+func (ie IndexExpr) Eval() (val.Value, error) {
+	x, err := ie.x.Eval()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := ie.idx.Eval()
+	if err != nil {
+		return nil, err
+	}
+
+	idxTok := ie.ast.Idx.Tok()
+
+	if bs, ok := x.(val.BitStr); ok {
+		return evalBitStrIndex(bs, idx, idxTok)
+	}
+
+	l, ok := x.(val.List)
+	if !ok {
+		return nil, tok.Error{
+			Msg:  fmt.Sprintf("cannot index value of type %s", x.Type()),
+			Toks: []tok.Token{ie.ast.X.Tok()},
+		}
+	}
+
+	switch idx := idx.(type) {
+	case val.Int:
+		if idx < 0 || int(idx) >= len(l) {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("index %d out of range, list length %d", idx, len(l)),
+				Toks: []tok.Token{idxTok},
+			}
+		}
+		return l[idx], nil
+	case val.Range:
+		if idx.L < 0 || idx.L > idx.R || int(idx.R) >= len(l) {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("slice %d:%d out of range, list length %d", idx.L, idx.R, len(l)),
+				Toks: []tok.Token{idxTok},
+			}
+		}
+		return append(val.List{}, l[idx.L:idx.R+1]...), nil
+	}
+
+	return nil, tok.Error{
+		Msg:  fmt.Sprintf("index must be of type integer or range, current type %s", idx.Type()),
+		Toks: []tok.Token{idxTok},
+	}
+}
+
+// evalBitStrIndex evaluates bit string index or slice.
+// Bits are indexed from the least significant bit.
+func evalBitStrIndex(bs val.BitStr, idx val.Value, idxTok tok.Token) (val.Value, error) {
+	width := bs.BitWidth()
+
+	switch idx := idx.(type) {
+	case val.Int:
+		if idx < 0 || int64(idx) >= width {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("bit index %d out of range, bit string width %d", idx, width),
+				Toks: []tok.Token{idxTok},
+			}
+		}
+		return bs.Slice(int64(idx), int64(idx)), nil
+	case val.Range:
+		if idx.L < 0 || idx.L > idx.R || idx.R >= width {
+			return nil, tok.Error{
+				Msg:  fmt.Sprintf("bit slice %d:%d out of range, bit string width %d", idx.L, idx.R, width),
+				Toks: []tok.Token{idxTok},
+			}
+		}
+		return bs.Slice(idx.L, idx.R), nil
+	}
+
+	return nil, tok.Error{
+		Msg:  fmt.Sprintf("index must be of type integer or range, current type %s", idx.Type()),
+		Toks: []tok.Token{idxTok},
+	}
+}
+
+func MakeIndexExpr(ie ast.IndexExpr, src []byte, s Scope) (IndexExpr, error) {
+	x, err := MakeExpr(ie.X, src, s)
+	if err != nil {
+		return IndexExpr{}, fmt.Errorf("make index expression: indexed expression: %v", err)
+	}
+
+	idx, err := MakeExpr(ie.Idx, src, s)
+	if err != nil {
+		return IndexExpr{}, fmt.Errorf("make index expression: index: %v", err)
+	}
+
+	return IndexExpr{ast: ie, x: x, idx: idx}, nil
+}
+
 type Int struct {
 	x int64
 }
@@ -286,6 +660,9 @@ func (l List) Eval() (val.Value, error) {
 	return val.List(vals), nil
 }
 
+// Exprs returns expressions of the list items.
+func (l List) Exprs() []Expr { return l.exprs }
+
 func MakeList(el ast.List, src []byte, s Scope) (List, error) {
 	exprs := []Expr{}
 
@@ -349,6 +726,9 @@ func (di DeclaredIdentifier) Eval() (val.Value, error) {
 	return x, nil
 }
 
+// Name returns the identifier name.
+func (di DeclaredIdentifier) Name() string { return di.x }
+
 func MakeDeclaredIdentifier(e ast.Ident, src []byte, s Scope) DeclaredIdentifier {
 	return DeclaredIdentifier{x: tok.Text(e.Name, src), s: s}
 }
@@ -371,6 +751,9 @@ func (qi QualifiedIdentifier) Eval() (val.Value, error) {
 	return x, nil
 }
 
+// Name returns the identifier name, including the package name.
+func (qi QualifiedIdentifier) Name() string { return qi.x }
+
 func MakeQualifiedIdentifier(e ast.QualIdent, src []byte, s Scope) QualifiedIdentifier {
 	return QualifiedIdentifier{x: tok.Text(e.Name, src), s: s}
 }
@@ -388,59 +771,6 @@ func MakeString(e ast.String, src []byte) String {
 	return String{x: txt[1 : len(txt)-1]}
 }
 
-/*
-type Subscript struct {
-	name string
-	idx  Expr
-	s    Scope
-}
-
-func (s Subscript) Eval() (val.Value, error) {
-	idx, err := s.idx.Eval()
-	if err != nil {
-		return val.Int(0), fmt.Errorf("subscript index evaluation:%v", err)
-	}
-
-	i, ok := idx.(val.Int)
-	if !ok {
-		return val.Int(0), fmt.Errorf("index must be of type 'integer', current type '%s'", idx.Type())
-	}
-
-	sym, err := s.s.GetSymbol(s.name, ConstDef)
-	if err != nil {
-		return val.Int(0), fmt.Errorf("subscript evaluation, cannot find symbol '%s'", s.name)
-	}
-
-	cons, ok := sym.(*Const)
-	if !ok {
-		return val.Int(0), fmt.Errorf("subscript evaluation, symbol '%s' is not a constant, type '%T'", s.name, sym)
-	}
-
-	exprList, ok := cons.Value.(ExpressionList)
-	if !ok {
-		return val.Int(0),
-			fmt.Errorf("subscript evaluation, constant '%s' is not expression list, type '%T'", s.name, cons.Value)
-	}
-
-	if int(i) >= len(exprList.exprs) {
-		return val.Int(0), fmt.Errorf("list '%s', index %d out of range", s.name, i)
-	}
-
-	return exprList.exprs[i].Eval()
-}
-
-func MakeSubscript(n ts.Node, s Scope) (Subscript, error) {
-	name := n.Child(0).Content()
-
-	idx, err := MakeExpr(n.Child(2), s)
-	if err != nil {
-		return Subscript{}, fmt.Errorf("make subscript: %v", err)
-	}
-
-	return Subscript{name: name, idx: idx, s: s}, nil
-}
-*/
-
 type Time struct {
 	v    Int
 	unit string
@@ -486,6 +816,7 @@ type UnaryOperator uint8
 const (
 	UnaryPlus = iota
 	UnaryMinus
+	UnaryNeg
 )
 
 type UnaryExpr struct {
@@ -499,14 +830,21 @@ func (ue UnaryExpr) Eval() (val.Value, error) {
 		return val.Int(0), fmt.Errorf("unary expression, operand: %v", err)
 	}
 
-	if x, ok := x.(val.Int); ok {
+	switch x := x.(type) {
+	case val.Int:
 		switch ue.op {
 		case UnaryPlus:
 			return x, nil
 		case UnaryMinus:
 			return -x, nil
-		default:
-			panic("operator not yet supported")
+		}
+	case val.Bool:
+		if ue.op == UnaryNeg {
+			return !x, nil
+		}
+	case val.BitStr:
+		if ue.op == UnaryNeg {
+			return x.Not(), nil
 		}
 	}
 
@@ -520,6 +858,8 @@ func MakeUnaryExpr(e ast.UnaryExpr, src []byte, s Scope) (UnaryExpr, error) {
 		op = UnaryPlus
 	case "-":
 		op = UnaryMinus
+	case "!":
+		op = UnaryNeg
 	default:
 		return UnaryExpr{}, fmt.Errorf("make unary expression: invalid operator %s", text)
 	}
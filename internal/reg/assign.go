@@ -1,6 +1,7 @@
 package reg
 
 import (
+	"log"
 	"sort"
 	"strings"
 
@@ -8,49 +9,250 @@ import (
 	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
 )
 
-func assignGlobalAccessAddresses(blk *fn.Block, baseAddr int64) {
-	if blk.IsArray {
-		blk.AddrSpace = types.SingleRange{
-			Start: baseAddr,
-			End:   baseAddr + blk.Count*blk.Sizes.Aligned - 1,
-		}
+// region represents a subblock, a blackbox or a memory address space within the block address space.
+type region struct {
+	typ    string
+	name   string
+	count  int64
+	size   int64  // Aligned size of a single element.
+	addr   *int64 // Fixed or locked start address relative to the block start address.
+	fun    *fn.Func
+	assign func(baseAddr int64)
+}
+
+// regionLess returns true if the region i must be placed at higher address than the region j.
+func regionLess(sizei, sizej int64, namei, namej string) bool {
+	if sizei < sizej {
+		return true
+	} else if sizei > sizej {
+		return false
 	} else {
-		blk.AddrSpace = types.SingleRange{
+		if strings.Compare(namei, namej) < 0 {
+			return true
+		} else {
+			return false
+		}
+	}
+}
+
+func addrSpace(f fn.Func, baseAddr int64, size int64) types.SingleRange {
+	if f.IsArray {
+		return types.SingleRange{
 			Start: baseAddr,
-			End:   baseAddr + blk.Sizes.Aligned - 1,
+			End:   baseAddr + f.Count*size - 1,
 		}
 	}
+	return types.SingleRange{
+		Start: baseAddr,
+		End:   baseAddr + size - 1,
+	}
+}
 
-	if len(blk.Subblocks) == 0 {
-		return
+// regionAddr returns the fixed address, or the address restored from the lock.
+func regionAddr(f *fn.Func, addr *int64) *int64 {
+	if addr != nil {
+		return addr
 	}
+	if a, ok := lockedRegionAddrs[f]; ok {
+		return &a
+	}
+	return nil
+}
 
-	sortFunc := func(i, j int) bool {
-		sizei := blk.Subblocks[i].Sizes.Aligned
-		sizej := blk.Subblocks[j].Sizes.Aligned
+// blockRegions returns regions of the block sorted in increasing size order.
+func blockRegions(blk *fn.Block) []region {
+	sort.Slice(blk.Subblocks, func(i, j int) bool {
+		return regionLess(
+			blk.Subblocks[i].Sizes.Aligned, blk.Subblocks[j].Sizes.Aligned,
+			blk.Subblocks[i].Name, blk.Subblocks[j].Name,
+		)
+	})
+	sort.Slice(blk.Blackboxes, func(i, j int) bool {
+		return regionLess(
+			blk.Blackboxes[i].Sizes.Aligned, blk.Blackboxes[j].Sizes.Aligned,
+			blk.Blackboxes[i].Name, blk.Blackboxes[j].Name,
+		)
+	})
+	sort.Slice(blk.Memories, func(i, j int) bool {
+		return regionLess(
+			blk.Memories[i].Sizes.Aligned, blk.Memories[j].Sizes.Aligned,
+			blk.Memories[i].Name, blk.Memories[j].Name,
+		)
+	})
 
-		namei := blk.Subblocks[i].Name
-		namej := blk.Subblocks[j].Name
+	regions := []region{}
+	for _, sb := range blk.Subblocks {
+		sb := sb
+		regions = append(regions, region{
+			typ:    "block",
+			name:   sb.Name,
+			count:  sb.Count,
+			size:   sb.Sizes.Aligned,
+			addr:   regionAddr(&sb.Func, sb.Addr),
+			fun:    &sb.Func,
+			assign: func(baseAddr int64) { assignGlobalAccessAddresses(sb, baseAddr) },
+		})
+	}
+	for _, bb := range blk.Blackboxes {
+		bb := bb
+		regions = append(regions, region{
+			typ:    "blackbox",
+			name:   bb.Name,
+			count:  bb.Count,
+			size:   bb.Sizes.Aligned,
+			addr:   regionAddr(&bb.Func, bb.Addr),
+			fun:    &bb.Func,
+			assign: func(baseAddr int64) { bb.AddrSpace = addrSpace(bb.Func, baseAddr, bb.Sizes.Aligned) },
+		})
+	}
+	for _, mem := range blk.Memories {
+		mem := mem
+		regions = append(regions, region{
+			typ:    "memory",
+			name:   mem.Name,
+			count:  mem.Count,
+			size:   mem.Sizes.Aligned,
+			addr:   regionAddr(&mem.Func, mem.Addr),
+			fun:    &mem.Func,
+			assign: func(baseAddr int64) { mem.AddrSpace = addrSpace(mem.Func, baseAddr, mem.Sizes.Aligned) },
+		})
+	}
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regionLess(regions[i].size, regions[j].size, regions[i].name, regions[j].name)
+	})
 
-		if sizei < sizej {
-			return true
-		} else if sizei > sizej {
-			return false
-		} else {
-			if strings.Compare(namei, namej) < 0 {
-				return true
-			} else {
-				return false
+	return regions
+}
+
+// checkFixedRegions checks whether regions with fixed address are naturally aligned,
+// and do not overlap the block own registers and each other.
+func checkFixedRegions(blk *fn.Block, regions []region) {
+	for i, r := range regions {
+		if r.addr == nil {
+			continue
+		}
+
+		if r.size > 0 && *r.addr%r.size != 0 {
+			log.Fatalf(
+				"block '%s': %s '%s' address %d is misaligned, it must be a multiple of %d",
+				blk.Name, r.typ, r.name, *r.addr, r.size,
+			)
+		}
+		if *r.addr < blk.Sizes.Own {
+			log.Fatalf(
+				"block '%s': %s '%s' at address %d overlaps block registers, addresses below %d are occupied",
+				blk.Name, r.typ, r.name, *r.addr, blk.Sizes.Own,
+			)
+		}
+
+		for _, r2 := range regions[i+1:] {
+			if r2.addr == nil {
+				continue
+			}
+			if *r.addr < *r2.addr+r2.count*r2.size && *r2.addr < *r.addr+r.count*r.size {
+				log.Fatalf(
+					"block '%s': %s '%s' at address %d overlaps %s '%s' at address %d",
+					blk.Name, r.typ, r.name, *r.addr, r2.typ, r2.name, *r2.addr,
+				)
 			}
 		}
 	}
-	sort.Slice(blk.Subblocks, sortFunc)
+}
+
+// layoutRegions returns start addresses of the regions relative to the block start address.
+// Regions with fixed address are placed first.
+// Placing remaining regions in decreasing size order from the block end keeps them
+// naturally aligned, as long as their sizes are powers of 2.
+// If the region overlaps a region with fixed address, it is moved below it.
+//
+// The second return is false if regions do not fit into the block of the given size.
+func layoutRegions(regions []region, own, size, align int64) ([]int64, bool) {
+	type span struct{ start, end int64 }
+
+	starts := make([]int64, len(regions))
+	placed := []span{}
+
+	for i, r := range regions {
+		if r.addr == nil {
+			continue
+		}
+		if *r.addr+r.count*r.size > size {
+			return nil, false
+		}
+		starts[i] = *r.addr
+		placed = append(placed, span{*r.addr, *r.addr + r.count*r.size})
+	}
+
+	top := size
+	// Iterate regions in decreasing size order.
+	for i := len(regions) - 1; i >= 0; i-- {
+		r := regions[i]
+		if r.addr != nil {
+			continue
+		}
+
+		length := r.count * r.size
+		alignment := align
+		if alignment == 0 {
+			alignment = max(r.size, 1)
+		}
+
+		start := top - length
+		for moved := true; moved; {
+			if start < own {
+				return nil, false
+			}
+			moved = false
+			for _, s := range placed {
+				if start < s.end && s.start < start+length {
+					start = s.start - length
+					start -= start % alignment
+					moved = true
+					break
+				}
+			}
+		}
+
+		starts[i] = start
+		placed = append(placed, span{start, start + length})
+		top = start
+	}
+
+	return starts, true
+}
+
+// fitRegions increases the block aligned size until all regions fit into the block.
+func fitRegions(blk *fn.Block, align int64) {
+	regions := blockRegions(blk)
+	checkFixedRegions(blk, regions)
+
+	for {
+		if _, ok := layoutRegions(regions, blk.Sizes.Own, blk.Sizes.Aligned, align); ok {
+			return
+		}
+
+		if align == 0 {
+			blk.Sizes.Aligned = max(2*blk.Sizes.Aligned, 1)
+		} else {
+			blk.Sizes.Aligned += align
+		}
+	}
+}
+
+func assignGlobalAccessAddresses(blk *fn.Block, baseAddr int64) {
+	blk.AddrSpace = addrSpace(blk.Func, baseAddr, blk.Sizes.Aligned)
+
+	if len(blk.Subblocks) == 0 && len(blk.Blackboxes) == 0 && len(blk.Memories) == 0 {
+		return
+	}
+
+	regions := blockRegions(blk)
+	starts, ok := layoutRegions(regions, blk.Sizes.Own, blk.Sizes.Aligned, blockAlign(blk))
+	if !ok {
+		panic("should never happen")
+	}
 
-	subblockBaseAddr := blk.AddrSpace.End + 1
-	// Iterate subblocks in decreasing size order.
-	for i := len(blk.Subblocks) - 1; i >= 0; i-- {
-		sb := blk.Subblocks[i]
-		subblockBaseAddr -= sb.Count * sb.Sizes.Aligned
-		assignGlobalAccessAddresses(sb, subblockBaseAddr)
+	for i, r := range regions {
+		r.assign(blk.AddrSpace.Start + starts[i])
 	}
 }
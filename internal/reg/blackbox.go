@@ -0,0 +1,20 @@
+package reg
+
+import (
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// regBlackbox sets and returns the blackbox sizes.
+// The aligned size is the size rounded up to the power of 2,
+// so that the blackbox address decoding requires only address bits comparison.
+func regBlackbox(bb *fn.Blackbox) types.Sizes {
+	bb.Sizes = types.Sizes{
+		Own:       bb.Size,
+		Cumulated: bb.Size,
+		Aligned:   util.AlignToPowerOf2(bb.Size),
+	}
+
+	return bb.Sizes
+}
@@ -0,0 +1,128 @@
+package reg
+
+import (
+	"log"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gap"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+// fixedRange represents registers occupied by a functionality with fixed address.
+type fixedRange struct {
+	name  string
+	start int64
+	end   int64
+}
+
+// Registers of functionalities with fixed address within the currently registerified block.
+var fixedRanges []fixedRange
+
+// Functionalities registerified before the automatic placement,
+// either with fixed address or restored from the lock.
+// Automatic placement skips them.
+var fixedFuncs map[fn.Functionality]bool
+
+func overlappingFixedRange(start, end int64) (fixedRange, bool) {
+	for _, r := range fixedRanges {
+		if start <= r.end && r.start <= end {
+			return r, true
+		}
+	}
+	return fixedRange{}, false
+}
+
+// regFixedFunctionalities registerifies functionalities with fixed address.
+// Addresses lower than startAddr are reserved.
+// Fixed functionalities have their own gap pools, so their registers are never shared.
+// The function returns the address following the last fixed register.
+func regFixedFunctionalities(blk *fn.Block, startAddr int64) int64 {
+	fixedRanges = nil
+	endAddr := startAddr
+
+	reg := func(f fn.Functionality, addr int64, regFunc func(addr int64) int64) {
+		typ, name := f.Type(), f.GetName()
+		if addr < startAddr {
+			log.Fatalf(
+				"block '%s': cannot place %s '%s' at address %d, addresses below %d are reserved",
+				blk.Name, typ, name, addr, startAddr,
+			)
+		}
+		end := regFunc(addr) - 1
+		if r, ok := overlappingFixedRange(addr, end); ok {
+			log.Fatalf(
+				"block '%s': %s '%s' at address %d overlaps '%s' at address %d",
+				blk.Name, typ, name, addr, r.name, r.start,
+			)
+		}
+		fixedRanges = append(fixedRanges, fixedRange{name: name, start: addr, end: end})
+		fixedFuncs[f] = true
+		if end+1 > endAddr {
+			endAddr = end + 1
+		}
+	}
+
+	for _, cfg := range blk.Configs {
+		if cfg.Addr == nil {
+			continue
+		}
+		reg(cfg, *cfg.Addr, func(addr int64) int64 {
+			if cfg.Atomic {
+				return regAtomicConfig(cfg, addr, &gap.Pool{})
+			}
+			return regNonAtomicConfig(cfg, addr, &gap.Pool{})
+		})
+	}
+	for _, mask := range blk.Masks {
+		if mask.Addr == nil {
+			continue
+		}
+		reg(mask, *mask.Addr, func(addr int64) int64 {
+			return regMask(mask, addr)
+		})
+	}
+	for _, st := range blk.Statics {
+		if st.Addr == nil {
+			continue
+		}
+		reg(st, *st.Addr, func(addr int64) int64 {
+			return regStatic(st, addr, &gap.Pool{})
+		})
+	}
+	for _, st := range blk.Statuses {
+		if st.Addr == nil {
+			continue
+		}
+		reg(st, *st.Addr, func(addr int64) int64 {
+			if st.Atomic {
+				return regAtomicStatus(st, addr, &gap.Pool{})
+			}
+			return regNonAtomicStatus(st, addr, &gap.Pool{})
+		})
+	}
+
+	return endAddr
+}
+
+// place registerifies functionality with regFunc starting from addr,
+// so that new registers do not overlap registers of functionalities with fixed address.
+// If they do, the functionality is registerified again after the overlapped fixed registers,
+// and the gap pool is restored, as gaps added by the discarded registerification are invalid.
+func place(addr int64, gp *gap.Pool, regFunc func(addr int64) int64) int64 {
+	for {
+		var saved gap.Pool
+		if gp != nil {
+			saved = gp.Copy()
+		}
+
+		end := regFunc(addr)
+		r, ok := overlappingFixedRange(addr, end-1)
+		if !ok {
+			return end
+		}
+
+		if gp != nil {
+			*gp = saved
+		}
+		addr = r.end + 1
+	}
+}
@@ -0,0 +1,136 @@
+package reg
+
+import (
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gap"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/block"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/group"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// regGroups registerifies non-virtual groups in the order of instantiation.
+// Each functionality is instantiated within exactly one group,
+// so there is no need to resolve the order of overlapping groups.
+//
+// Functionalities of the group are placed in a single address window, one after another.
+// The group has its own gap pool, so neither group functionalities are placed outside
+// the window, nor other functionalities are placed within the window.
+// This allows reading or writing the whole group with a single burst access.
+func regGroups(blk *fn.Block, addr int64) int64 {
+	for _, grp := range blk.Groups {
+		if grp.Virtual {
+			continue
+		} else if lockedGroups[grp] && !hasUnfixedFunctionalities(grp) {
+			addGroupFunctionalities(blk, grp)
+			continue
+		}
+
+		// The whole window is placed around registers of functionalities with fixed address.
+		// For locked groups, the window contains only functionalities added to the group.
+		addr = place(addr, nil, func(addr int64) int64 {
+			if len(grp.Irqs) > 0 {
+				return regIrqGroup(grp, addr)
+			}
+			gp := gap.Pool{}
+			addr = regConfigs(grp.Configs, addr, &gp)
+			addr = regMasks(grp.Masks, addr)
+			addr = regStatics(grp.Statics, addr, &gp)
+			return regStatuses(grp.Statuses, addr, &gp)
+		})
+
+		addGroupFunctionalities(blk, grp)
+	}
+
+	return addr
+}
+
+// hasUnfixedFunctionalities returns true if any group functionality does not have a fixed address.
+func hasUnfixedFunctionalities(grp *fn.Group) bool {
+	for _, f := range group.Functionalities(grp) {
+		if !fixedFuncs[f] {
+			return true
+		}
+	}
+	return false
+}
+
+// regIrqGroup packs irqs of the group as consecutive bits of the flag registers.
+// Irq arrays occupy as many consecutive bits as they have items.
+// An array which does not fit into the rest of the current register starts at a new register.
+// If any irq has an enable, the enable bits are placed at the same positions
+// of the enable registers following the flag registers.
+// Explicitly cleared irqs are cleared by writing the (first) flag register.
+func regIrqGroup(grp *fn.Group, addr int64) int64 {
+	// Register offset and start bit of every irq within the flag registers.
+	regs := make([]int64, len(grp.Irqs))
+	bits := make([]int64, len(grp.Irqs))
+
+	pos := int64(0) // Bit position from the start of the flag registers
+	for i, irq := range grp.Irqs {
+		count := int64(1)
+		if irq.IsArray {
+			count = irq.Count
+		}
+		if pos%busWidth+count > busWidth && pos%busWidth != 0 {
+			pos += busWidth - pos%busWidth
+		}
+		regs[i] = pos / busWidth
+		bits[i] = pos % busWidth
+		pos += count
+	}
+
+	regCount := (pos + busWidth - 1) / busWidth
+	hasEnable := false
+
+	makeAccess := func(irq *fn.Irq, addr, bit int64) types.Access {
+		if !irq.IsArray {
+			return types.MakeSingleAccess(addr, bit, 1)
+		} else if bit+irq.Count <= busWidth {
+			return types.MakeArrayOneRegAccess(irq.Count, addr, bit, 1)
+		} else if irq.Count%busWidth == 0 {
+			return types.MakeArrayNInRegAccess(irq.Count, addr, 1)
+		}
+		return types.MakeArrayNInRegMInEndRegAccess(irq.Count, addr, 1)
+	}
+
+	for i, irq := range grp.Irqs {
+		irqAddr := addr + regs[i]
+
+		irq.Access = makeAccess(irq, irqAddr, bits[i])
+		if irq.AddEnable {
+			irq.EnableAccess = makeAccess(irq, irqAddr+regCount, bits[i])
+			hasEnable = true
+		}
+		if irq.Clear == "Explicit" {
+			clrAddr := irqAddr
+			irq.ClearAddr = &clrAddr
+		}
+	}
+
+	addr += regCount
+	if hasEnable {
+		addr += regCount
+	}
+
+	return addr
+}
+
+// addGroupFunctionalities adds group functionalities to the block,
+// so that they are accessible as regular block functionalities.
+func addGroupFunctionalities(blk *fn.Block, grp *fn.Group) {
+	for _, c := range grp.Configs {
+		block.AddConfig(blk, c)
+	}
+	for _, i := range grp.Irqs {
+		block.AddIrq(blk, i)
+	}
+	for _, m := range grp.Masks {
+		block.AddMask(blk, m)
+	}
+	for _, s := range grp.Statics {
+		block.AddStatic(blk, s)
+	}
+	for _, s := range grp.Statuses {
+		block.AddStatus(blk, s)
+	}
+}
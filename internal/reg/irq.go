@@ -19,8 +19,8 @@ func regIrq(irq *fn.Irq, addr int64, gp *gap.Pool) int64 {
 //
 // As irqs are registerified as the last ones, the function doesn't add any gap to the pool.
 func regIrqSingle(irq *fn.Irq, addr int64, gp *gap.Pool) int64 {
-	if !irq.AddEnable && irq.Clear == "Explicit" {
-		if g, ok := gp.GetSingle(1, true); ok {
+	if !irq.AddEnable && irq.Clear == "Explicit" && packing != "One Per Register" {
+		if g, ok := gp.GetSingle(1, 1, true); ok {
 			irq.Access = types.MakeSingleAccess(g.Addr, g.StartBit, 1)
 			clrAddr := g.Addr
 			irq.ClearAddr = &clrAddr
@@ -45,6 +45,33 @@ func regIrqSingle(irq *fn.Irq, addr int64, gp *gap.Pool) int64 {
 	return addr
 }
 
+// Irq array flags are packed into consecutive registers.
+// If enable is added, enable bits are placed at the same positions of the registers
+// following the flag registers.
+// Explicit clear is done by writing ones to the flag registers, as they contain no writable bits.
+// The clear address is the address of the first flag register,
+// the item is cleared at the same register offset as its flag.
 func regIrqArray(irq *fn.Irq, addr int64) int64 {
-	panic("unimplemented")
+	makeAccess := func(addr int64) types.Access {
+		if irq.Count <= busWidth {
+			return types.MakeArrayOneRegAccess(irq.Count, addr, 0, 1)
+		} else if irq.Count%busWidth == 0 {
+			return types.MakeArrayNInRegAccess(irq.Count, addr, 1)
+		}
+		return types.MakeArrayNInRegMInEndRegAccess(irq.Count, addr, 1)
+	}
+
+	irq.Access = makeAccess(addr)
+	if irq.Clear == "Explicit" {
+		clrAddr := addr
+		irq.ClearAddr = &clrAddr
+	}
+	addr += irq.Access.RegCount
+
+	if irq.AddEnable {
+		irq.EnableAccess = makeAccess(addr)
+		addr += irq.EnableAccess.RegCount
+	}
+
+	return addr
 }
@@ -0,0 +1,216 @@
+package reg
+
+import (
+	"log"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/lock"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/block"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/group"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+// Lock of the previous registerification, nil if addresses are not locked.
+var lck *lock.Lock
+
+// Start addresses of subblocks, blackboxes and memories restored from the lock,
+// relative to the parent block start address.
+var lockedRegionAddrs map[*fn.Func]int64
+
+// Groups with functionalities restored from the lock.
+var lockedGroups map[*fn.Group]bool
+
+// regLockedFunctionalities restores addresses of functionalities from the lock.
+// It must be called after functionalities with fixed address are registerified,
+// as the lock cannot be honoured if registers overlap registers of fixed functionalities.
+// Addresses lower than startAddr are reserved.
+// Functionalities of non-virtual groups are restored only if all locked group functionalities can be restored,
+// so the group address window is preserved.
+// Functionalities added to a locked group are placed in a separate window in the free space.
+// Irq groups are restored only if no irq was added, as all irqs of the group share flag registers.
+// The function returns the address following the last locked register.
+func regLockedFunctionalities(blk *fn.Block, path string, startAddr int64) int64 {
+	endAddr := startAddr
+	if lck == nil {
+		return endAddr
+	}
+
+	locked := []fixedRange{}
+
+	// canLock returns an empty string if the functionality can be restored from the lock,
+	// otherwise it returns the reason why the lock cannot be honoured.
+	canLock := func(f fn.Functionality) (lock.Func, string) {
+		lf, ok := lck.Funcs[path+"."+f.GetName()]
+		if !ok {
+			return lf, "not locked"
+		}
+		if err := lock.Check(f, lf); err != nil {
+			return lf, err.Error()
+		}
+		for _, r := range lf.Regs() {
+			if r.Start < startAddr {
+				return lf, "registers overlap reserved addresses"
+			}
+			if fr, ok := overlappingFixedRange(r.Start, r.End); ok {
+				return lf, "registers overlap '" + fr.name + "' with fixed address"
+			}
+		}
+		return lf, ""
+	}
+
+	apply := func(f fn.Functionality, lf lock.Func) {
+		if err := lock.Apply(f, lf); err != nil {
+			panic("should never happen")
+		}
+		fixedFuncs[f] = true
+		for _, r := range lf.Regs() {
+			locked = append(locked, fixedRange{name: f.GetName(), start: r.Start, end: r.End})
+			if r.End+1 > endAddr {
+				endAddr = r.End + 1
+			}
+		}
+	}
+
+	for _, f := range block.OwnFunctionalities(blk) {
+		if fixedFuncs[f] {
+			continue
+		}
+		lf, reason := canLock(f)
+		if reason == "not locked" {
+			continue
+		} else if reason != "" {
+			log.Printf(
+				"warning: lock: block '%s': cannot restore %s '%s' address, %s",
+				blk.Name, f.Type(), f.GetName(), reason,
+			)
+			continue
+		}
+		apply(f, lf)
+	}
+
+	for _, grp := range blk.Groups {
+		if grp.Virtual {
+			continue
+		}
+
+		fns := group.Functionalities(grp)
+		lockedFns := []fn.Functionality{}
+		lfs := []lock.Func{}
+		newFns := []string{}
+		reason := ""
+		for _, f := range fns {
+			lf, r := canLock(f)
+			if r == "not locked" {
+				newFns = append(newFns, f.GetName())
+				continue
+			} else if r != "" {
+				reason = r
+				break
+			}
+			lockedFns = append(lockedFns, f)
+			lfs = append(lfs, lf)
+		}
+		if reason == "" && len(lockedFns) == 0 {
+			continue
+		} else if reason == "" && len(newFns) > 0 && len(grp.Irqs) > 0 {
+			reason = "irqs '" + strings.Join(newFns, "', '") + "' were added to the irq group"
+		}
+		if reason != "" {
+			log.Printf(
+				"warning: lock: block '%s': cannot restore group '%s' address, %s",
+				blk.Name, grp.Name, reason,
+			)
+			continue
+		}
+		if len(newFns) > 0 {
+			log.Printf(
+				"warning: lock: block '%s': group '%s': functionalities '%s' were added to the locked group, "+
+					"they are placed outside the locked group address window",
+				blk.Name, grp.Name, strings.Join(newFns, "', '"),
+			)
+		}
+
+		for i, f := range lockedFns {
+			apply(f, lfs[i])
+		}
+		lockedGroups[grp] = true
+	}
+
+	// Locked registers are added after all functionalities are restored,
+	// as locked functionalities may share registers.
+	fixedRanges = append(fixedRanges, locked...)
+
+	return endAddr
+}
+
+// lockRegions restores start addresses of subblocks, blackboxes and memories from the lock.
+// The block own size must already be known.
+func lockRegions(blk *fn.Block, path string) {
+	if lck == nil {
+		return
+	}
+	parent, ok := lck.AddrSpaces[path]
+	if !ok {
+		return
+	}
+
+	regions := blockRegions(blk)
+	for _, r := range regions {
+		if r.addr != nil {
+			continue
+		}
+		ls, ok := lck.AddrSpaces[path+"."+r.name]
+		if !ok {
+			continue
+		}
+
+		addr := ls.Start - parent.Start
+		length := r.count * r.size
+		reason := ""
+		if addr < blk.Sizes.Own {
+			reason = "it overlaps block registers"
+		} else if r.size > 0 && addr%r.size != 0 {
+			reason = "it is misaligned"
+		} else {
+			for _, r2 := range regions {
+				if r2.addr == nil || r2.name == r.name {
+					continue
+				}
+				if addr < *r2.addr+r2.count*r2.size && *r2.addr < addr+length {
+					reason = "it overlaps " + r2.typ + " '" + r2.name + "'"
+					break
+				}
+			}
+		}
+		if reason != "" {
+			log.Printf(
+				"warning: lock: block '%s': cannot restore %s '%s' address %d, %s",
+				blk.Name, r.typ, r.name, addr, reason,
+			)
+			continue
+		}
+
+		lockedRegionAddrs[r.fun] = addr
+		// Following regions must not overlap the restored one.
+		regions = blockRegions(blk)
+	}
+}
+
+// lockedBaseAddr returns the main bus base address restored from the lock.
+func lockedBaseAddr(bus *fn.Block) int64 {
+	if lck == nil {
+		return 0
+	}
+	ls, ok := lck.AddrSpaces[bus.Name]
+	if !ok {
+		return 0
+	}
+	if ls.Start%bus.Sizes.Aligned != 0 {
+		log.Printf(
+			"warning: lock: cannot restore main bus base address %d, it is misaligned, it must be a multiple of the bus size %d",
+			ls.Start, bus.Sizes.Aligned,
+		)
+		return 0
+	}
+	return ls.Start
+}
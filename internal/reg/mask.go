@@ -10,17 +10,8 @@ func regMask(mask *fn.Mask, addr int64) int64 {
 	var acs types.Access
 
 	if mask.IsArray {
-		panic("unimplemented")
-		/* Should it be implemented the same way as for Status?
-		if width == busWidth {
-
-		} else if busWidth%width == 0 || insMask.Count < busWidth/width {
-			mask.Access = types.MakeArrayMultiple(mask.Count, addr, width)
-			// TODO: This is a place for adding a potential Gap.
-		} else {
-			panic("unimplemented")
-		}
-		*/
+		// TODO: A potential gap can be added.
+		acs = makeArrayAccess(mask.Count, addr, mask.Width)
 	} else {
 		acs = types.MakeSingleAccess(addr, 0, mask.Width)
 	}
@@ -0,0 +1,22 @@
+package reg
+
+import (
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// regMemory sets and returns the memory sizes.
+// Each memory word occupies the same number of registers.
+// Similarly to the blackbox, the aligned size is the size rounded up to the power of 2,
+// so that the memory window is naturally aligned.
+func regMemory(mem *fn.Memory) types.Sizes {
+	size := mem.Size * mem.RegsPerWord(busWidth)
+	mem.Sizes = types.Sizes{
+		Own:       size,
+		Cumulated: size,
+		Aligned:   util.AlignToPowerOf2(size),
+	}
+
+	return mem.Sizes
+}
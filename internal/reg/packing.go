@@ -0,0 +1,99 @@
+package reg
+
+import (
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gap"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// Packing strategy of the currently registerified block.
+//
+// "Compact" places functionalities in any fitting gap.
+// "One Per Register" never places functionalities in gaps,
+// so each functionality starts at a new register.
+// "Natural Alignment" places functionalities only in gaps where
+// the start bit is a multiple of the width rounded up to the power of 2.
+var packing string
+
+// gapAlign returns the gap start bit alignment for an item of the given width.
+func gapAlign(width int64) int64 {
+	if packing == "Natural Alignment" {
+		return util.AlignToPowerOf2(width)
+	}
+	return 1
+}
+
+// addGaps adds gaps left by the access to the pool.
+// readOnly indicates whether the access is read only,
+// gaps in registers with writable bits are not write safe.
+func addGaps(gp *gap.Pool, acs types.Access, readOnly bool) {
+	if packing == "One Per Register" || acs.EndBit == busWidth-1 {
+		return
+	}
+
+	switch acs.Type {
+	case "SingleOneReg", "SingleNRegs", "ArrayOneReg":
+		gp.Add(gap.Single{Addr: acs.EndAddr, StartBit: acs.EndBit + 1, EndBit: busWidth - 1, WriteSafe: readOnly})
+	case "ArrayOneInReg", "ArrayNInReg":
+		gp.Add(gap.Array{
+			StartAddr: acs.StartAddr, EndAddr: acs.EndAddr,
+			StartBit: acs.EndBit + 1, EndBit: busWidth - 1, WriteSafe: readOnly,
+		})
+	case "ArrayNInRegMInEndReg":
+		if acs.StartRegWidth < busWidth {
+			gp.Add(gap.Array{
+				StartAddr: acs.StartAddr, EndAddr: acs.EndAddr - 1,
+				StartBit: acs.StartRegWidth, EndBit: busWidth - 1, WriteSafe: readOnly,
+			})
+		}
+		gp.Add(gap.Single{Addr: acs.EndAddr, StartBit: acs.EndBit + 1, EndBit: busWidth - 1, WriteSafe: readOnly})
+	case "ArrayOneInNRegs":
+		// The last register of each item has a gap.
+		regsPerItem := acs.RegCount / acs.ItemCount
+		for addr := acs.StartAddr + regsPerItem - 1; addr <= acs.EndAddr; addr += regsPerItem {
+			gp.Add(gap.Single{Addr: addr, StartBit: acs.EndBit + 1, EndBit: busWidth - 1, WriteSafe: readOnly})
+		}
+	}
+}
+
+// makeSingleAccessInGap makes access for a single item, placing it in a gap if possible.
+// The function returns the access and the address of the first free register.
+func makeSingleAccessInGap(width, addr int64, gp *gap.Pool, readOnly bool) (types.Access, int64) {
+	if width <= busWidth && packing != "One Per Register" {
+		if g, ok := gp.GetSingle(width, gapAlign(width), !readOnly); ok {
+			return types.MakeSingleAccess(g.Addr, g.StartBit, width), addr
+		}
+	}
+
+	acs := types.MakeSingleAccess(addr, 0, width)
+	addGaps(gp, acs, readOnly)
+
+	return acs, addr + acs.RegCount
+}
+
+// placeArrayAccessInGap moves the array access, made for the given address, to a gap if possible.
+// Only arrays with all items within one register, or with one item per register,
+// can be placed in a gap.
+// The function returns the access and the address of the first free register.
+func placeArrayAccessInGap(acs types.Access, addr int64, gp *gap.Pool, readOnly bool) (types.Access, int64) {
+	if packing != "One Per Register" {
+		count := acs.ItemCount
+		width := acs.ItemWidth
+		align := gapAlign(width)
+
+		switch acs.Type {
+		case "ArrayOneReg":
+			if g, ok := gp.GetSingle(count*width, align, !readOnly); ok {
+				return types.MakeArrayOneRegAccess(count, g.Addr, g.StartBit, width), addr
+			}
+		case "ArrayOneInReg":
+			if g, ok := gp.GetArray(count, width, align, !readOnly); ok {
+				return types.MakeArrayOneInRegAccess(count, g.StartAddr, g.StartBit, width), addr
+			}
+		}
+	}
+
+	addGaps(gp, acs, readOnly)
+
+	return acs, addr + acs.RegCount
+}
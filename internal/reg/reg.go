@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/gap"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/lock"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/block"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/hash"
@@ -16,16 +17,26 @@ import (
 var busAlign int64
 var busWidth int64
 
-func Registerify(bus *fn.Block, addTimestamp bool) {
+// Registerify registerifies the bus.
+// If lock is not nil, addresses are restored from the lock whenever possible.
+func Registerify(bus *fn.Block, addTimestamp bool, lock *lock.Lock) {
 	busAlign = bus.Align
 	busWidth = bus.Width
 	types.Init(busWidth)
 
+	lck = lock
+	if bus.Packing == "" {
+		bus.Packing = "Compact"
+	}
+	fixedFuncs = map[fn.Functionality]bool{}
+	lockedRegionAddrs = map[*fn.Func]int64{}
+	lockedGroups = map[*fn.Group]bool{}
+
 	// addr is currently block internal access address, not global address.
 	// 0 is reserved for ID, even if ID is not generated.
 	addr := int64(1)
 
-	addr = regFunctionalities(bus, addr)
+	addr = regFunctionalities(bus, bus.Name, addr)
 
 	timestampAddr := addr
 	if addTimestamp {
@@ -38,15 +49,41 @@ func Registerify(bus *fn.Block, addTimestamp bool) {
 	sizes.Cumulated = addr
 
 	for _, sb := range bus.Subblocks {
-		sbSizes := regBlock(sb)
+		if sb.Packing == "" {
+			sb.Packing = bus.Packing
+		}
+		sbSizes := regBlock(sb, bus.Name+"."+sb.Name)
 		sizes.Cumulated += sb.Count * sbSizes.Cumulated
 		sizes.Aligned += sb.Count * sbSizes.Aligned
 	}
+	for _, bb := range bus.Blackboxes {
+		bbSizes := regBlackbox(bb)
+		sizes.Cumulated += bb.Count * bbSizes.Cumulated
+		sizes.Aligned += bb.Count * bbSizes.Aligned
+	}
+	for _, mem := range bus.Memories {
+		memSizes := regMemory(mem)
+		sizes.Cumulated += mem.Count * memSizes.Cumulated
+		sizes.Aligned += mem.Count * memSizes.Aligned
+	}
 
 	bus.Sizes = alignBlockSize(sizes, bus.Align)
+	lockRegions(bus, bus.Name)
+	fitRegions(bus, bus.Align)
 
-	// Base address property is not yet supported, so it starts from 0.
-	assignGlobalAccessAddresses(bus, 0)
+	baseAddr := int64(0)
+	if bus.Addr == nil {
+		baseAddr = lockedBaseAddr(bus)
+	} else {
+		baseAddr = *bus.Addr
+		if baseAddr%bus.Sizes.Aligned != 0 {
+			log.Fatalf(
+				"main bus base address %d is misaligned, it must be a multiple of the bus size %d",
+				baseAddr, bus.Sizes.Aligned,
+			)
+		}
+	}
+	assignGlobalAccessAddresses(bus, baseAddr)
 
 	if block.HasFunctionality(bus, "ID") {
 		log.Fatalf("'ID' is reserved functionality name in main bus")
@@ -70,50 +107,57 @@ func Registerify(bus *fn.Block, addTimestamp bool) {
 		ts.Access = types.MakeSingleAccess(timestampAddr, 0, busWidth)
 		bus.Statics = append(bus.Statics, ts)
 	}
+
+	checkRMW(bus)
 }
 
-func regFunctionalities(blk *fn.Block, addr int64) int64 {
+// path is the hierarchical name of the block used as the lock key.
+func regFunctionalities(blk *fn.Block, path string, addr int64) int64 {
+	packing = blk.Packing
 	gp := gap.Pool{}
 
+	// Functionalities with fixed address are registerified as the first ones,
+	// the remaining ones are placed around them.
+	// Virtual group functionalities are regular block functionalities, so they can have fixed address.
+	for _, grp := range blk.Groups {
+		if grp.Virtual {
+			addGroupFunctionalities(blk, grp)
+		}
+	}
+	fixedEnd := regFixedFunctionalities(blk, addr)
+	lockedEnd := regLockedFunctionalities(blk, path, addr)
+
 	addr = regProcs(blk, addr)
 	addr = regStreams(blk, addr)
-	//addr = regGroups(blk, addr)
-
-	addr = regConfigs(blk, addr, &gp)
-	addr = regMasks(blk, addr)
-	addr = regStatics(blk, addr, &gp)
-	addr = regStatuses(blk, addr, &gp)
 
-	// Registerify irqs as the last ones.
-	// Single irqs have a width of 1, so they can easily fit gaps.
-	addr = regIrqs(blk, addr, &gp)
+	// Functionalities of non-virtual groups are added to the block
+	// while registerifying groups, so block functionalities are captured before.
+	cfgs := blk.Configs
+	masks := blk.Masks
+	statics := blk.Statics
+	statuses := blk.Statuses
+	irqs := blk.Irqs
 
-	return addr
-}
+	addr = regGroups(blk, addr)
 
-/*
-func regGroups(blk *fn.Block, insBlk *ins.Element, addr int64) int64 {
-	var grp fn.Group
-	for _, g := range insBlk.Grps {
-		if g.IsStatus() && g.IsArray() {
-			grp, addr = regGroupStatusArray(blk, g, addr)
-		} else {
-			panic("unimplemented")
-		}
+	addr = regConfigs(cfgs, addr, &gp)
+	addr = regMasks(masks, addr)
+	addr = regStatics(statics, addr, &gp)
+	addr = regStatuses(statuses, addr, &gp)
 
-		blkAddGroup(blk, grp)
-		for _, st := range grp.Statuses() {
-			blkAddStatus(blk, st)
-		}
-	}
+	// Registerify irqs as the last ones.
+	// Single irqs have a width of 1, so they can easily fit gaps.
+	addr = regIrqs(irqs, addr, &gp)
 
-	return addr
+	return max(addr, fixedEnd, lockedEnd)
 }
-*/
 
 func regProcs(blk *fn.Block, addr int64) int64 {
 	for _, fun := range blk.Procs {
-		addr = regProc(fun, addr)
+		if fixedFuncs[fun] {
+			continue
+		}
+		addr = place(addr, nil, func(addr int64) int64 { return regProc(fun, addr) })
 	}
 
 	return addr
@@ -121,23 +165,33 @@ func regProcs(blk *fn.Block, addr int64) int64 {
 
 func regStreams(blk *fn.Block, addr int64) int64 {
 	for _, stream := range blk.Streams {
-		addr = regStream(stream, addr)
+		if fixedFuncs[stream] {
+			continue
+		}
+		addr = place(addr, nil, func(addr int64) int64 { return regStream(stream, addr) })
 	}
 
 	return addr
 }
 
-func regMasks(blk *fn.Block, addr int64) int64 {
-	for _, mask := range blk.Masks {
-		addr = regMask(mask, addr)
+func regMasks(masks []*fn.Mask, addr int64) int64 {
+	for _, mask := range masks {
+		if fixedFuncs[mask] {
+			continue
+		}
+		addr = place(addr, nil, func(addr int64) int64 { return regMask(mask, addr) })
 	}
 
 	return addr
 }
 
-func regStatics(blk *fn.Block, addr int64, gp *gap.Pool) int64 {
+func regStatics(sts []*fn.Static, addr int64, gp *gap.Pool) int64 {
 	statics := []*fn.Static{}
-	statics = append(statics, blk.Statics...)
+	for _, st := range sts {
+		if !fixedFuncs[st] {
+			statics = append(statics, st)
+		}
+	}
 
 	sortFunc := func(sts []*fn.Static) func(int, int) bool {
 		return func(i, j int) bool {
@@ -157,18 +211,20 @@ func regStatics(blk *fn.Block, addr int64, gp *gap.Pool) int64 {
 	sort.SliceStable(statics, sortFunc(statics))
 
 	for _, st := range statics {
-		addr = regStatic(st, addr, gp)
+		addr = place(addr, gp, func(addr int64) int64 { return regStatic(st, addr, gp) })
 	}
 
 	return addr
 }
 
-func regStatuses(blk *fn.Block, addr int64, gp *gap.Pool) int64 {
+func regStatuses(sts []*fn.Status, addr int64, gp *gap.Pool) int64 {
 	atomicSts := []*fn.Status{}
 	nonAtomicSts := []*fn.Status{}
 
-	for _, st := range blk.Statuses {
-		if st.Atomic {
+	for _, st := range sts {
+		if fixedFuncs[st] {
+			continue
+		} else if st.Atomic {
 			atomicSts = append(atomicSts, st)
 		} else {
 			nonAtomicSts = append(nonAtomicSts, st)
@@ -194,28 +250,33 @@ func regStatuses(blk *fn.Block, addr int64, gp *gap.Pool) int64 {
 	sort.SliceStable(nonAtomicSts, sortFunc(nonAtomicSts))
 
 	for _, st := range atomicSts {
-		addr = regAtomicStatus(st, addr, gp)
+		addr = place(addr, gp, func(addr int64) int64 { return regAtomicStatus(st, addr, gp) })
 	}
 	for _, st := range nonAtomicSts {
-		addr = regNonAtomicStatus(st, addr, gp)
+		addr = place(addr, gp, func(addr int64) int64 { return regNonAtomicStatus(st, addr, gp) })
 	}
 
 	return addr
 }
 
-func regIrqs(blk *fn.Block, addr int64, gp *gap.Pool) int64 {
-	for _, irq := range blk.Irqs {
-		addr = regIrq(irq, addr, gp)
+func regIrqs(irqs []*fn.Irq, addr int64, gp *gap.Pool) int64 {
+	for _, irq := range irqs {
+		if fixedFuncs[irq] {
+			continue
+		}
+		addr = place(addr, gp, func(addr int64) int64 { return regIrq(irq, addr, gp) })
 	}
 	return addr
 }
 
-func regConfigs(blk *fn.Block, addr int64, gp *gap.Pool) int64 {
+func regConfigs(cfgs []*fn.Config, addr int64, gp *gap.Pool) int64 {
 	atomicCfgs := []*fn.Config{}
 	nonAtomicCfgs := []*fn.Config{}
 
-	for _, cfg := range blk.Configs {
-		if cfg.Atomic {
+	for _, cfg := range cfgs {
+		if fixedFuncs[cfg] {
+			continue
+		} else if cfg.Atomic {
 			atomicCfgs = append(atomicCfgs, cfg)
 		} else {
 			nonAtomicCfgs = append(nonAtomicCfgs, cfg)
@@ -241,37 +302,56 @@ func regConfigs(blk *fn.Block, addr int64, gp *gap.Pool) int64 {
 	sort.SliceStable(nonAtomicCfgs, sortFunc(nonAtomicCfgs))
 
 	for _, cfg := range atomicCfgs {
-		addr = regAtomicConfig(cfg, addr, gp)
+		addr = place(addr, gp, func(addr int64) int64 { return regAtomicConfig(cfg, addr, gp) })
 	}
 	for _, cfg := range nonAtomicCfgs {
-		addr = regNonAtomicConfig(cfg, addr, gp)
+		addr = place(addr, gp, func(addr int64) int64 { return regNonAtomicConfig(cfg, addr, gp) })
 	}
 
 	return addr
 }
 
-func regBlock(blk *fn.Block) types.Sizes {
+func regBlock(blk *fn.Block, path string) types.Sizes {
 	addr := int64(0)
 
-	addr = regFunctionalities(blk, addr)
+	addr = regFunctionalities(blk, path, addr)
 	sizes := types.Sizes{Own: addr, Cumulated: addr, Aligned: 0}
 
 	for _, sb := range blk.Subblocks {
-		b := regBlock(sb)
+		if sb.Packing == "" {
+			sb.Packing = blk.Packing
+		}
+		b := regBlock(sb, path+"."+sb.Name)
 		sizes.Cumulated += sb.Count * b.Cumulated
 		sizes.Aligned += sb.Count * b.Aligned
 	}
-
-	align := blk.Align
-	if align == 0 {
-		align = busAlign
+	for _, bb := range blk.Blackboxes {
+		b := regBlackbox(bb)
+		sizes.Cumulated += bb.Count * b.Cumulated
+		sizes.Aligned += bb.Count * b.Aligned
+	}
+	for _, mem := range blk.Memories {
+		m := regMemory(mem)
+		sizes.Cumulated += mem.Count * m.Cumulated
+		sizes.Aligned += mem.Count * m.Aligned
 	}
 
+	align := blockAlign(blk)
 	blk.Sizes = alignBlockSize(sizes, align)
+	lockRegions(blk, path)
+	fitRegions(blk, align)
 
 	return blk.Sizes
 }
 
+// blockAlign returns the block alignment, bus alignment is the default one.
+func blockAlign(blk *fn.Block) int64 {
+	if blk.Align == 0 {
+		return busAlign
+	}
+	return blk.Align
+}
+
 func alignBlockSize(sizes types.Sizes, align int64) types.Sizes {
 	if align == 0 {
 		sizes.Aligned = util.AlignToPowerOf2(util.AlignToPowerOf2(sizes.Own) + sizes.Aligned)
@@ -281,3 +361,18 @@ func alignBlockSize(sizes types.Sizes, align int64) types.Sizes {
 
 	return sizes
 }
+
+// makeArrayAccess makes the most compact array access starting from bit 0 of the address.
+// Items narrower than the bus are never split between registers.
+func makeArrayAccess(count, addr, width int64) types.Access {
+	if count*width <= busWidth {
+		return types.MakeArrayOneRegAccess(count, addr, 0, width)
+	} else if busWidth/2 < width && width <= busWidth {
+		return types.MakeArrayOneInRegAccess(count, addr, 0, width)
+	} else if width <= busWidth/2 && count%(busWidth/width) == 0 {
+		return types.MakeArrayNInRegAccess(count, addr, width)
+	} else if width <= busWidth/2 {
+		return types.MakeArrayNInRegMInEndRegAccess(count, addr, width)
+	}
+	return types.MakeArrayOneInNRegsAccess(count, addr, width)
+}
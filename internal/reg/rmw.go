@@ -0,0 +1,54 @@
+package reg
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/regmap"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// findSharedRegs finds registers shared by multiple writable functionalities,
+// within the block and all its subblocks.
+// Writing such a register requires read-modify-write operation.
+// The function returns descriptions of found registers.
+func findSharedRegs(blk *fn.Block, path string) []string {
+	descs := []string{}
+
+	blk.SharedRegs = nil
+	for _, r := range regmap.Make(blk) {
+		funcs := r.WritableFuncs()
+		if len(funcs) < 2 {
+			continue
+		}
+		blk.SharedRegs = append(blk.SharedRegs, types.SharedReg{Addr: r.Addr, Funcs: funcs})
+		descs = append(descs, fmt.Sprintf("%s register %d: %s", path, r.Addr, strings.Join(funcs, ", ")))
+	}
+
+	for _, sb := range blk.Subblocks {
+		descs = append(descs, findSharedRegs(sb, path+"."+sb.Name)...)
+	}
+
+	return descs
+}
+
+// checkRMW reports registers shared by multiple writable functionalities,
+// and fails if read-modify-write is forbidden for the bus.
+func checkRMW(bus *fn.Block) {
+	descs := findSharedRegs(bus, bus.Name)
+	if len(descs) == 0 {
+		return
+	}
+
+	if bus.ForbidRMW {
+		log.Fatalf(
+			"error: read-modify-write is forbidden, registers shared by multiple writable functionalities:\n  %s",
+			strings.Join(descs, "\n  "),
+		)
+	}
+	for _, d := range descs {
+		log.Printf("warning: read-modify-write required, %s", d)
+	}
+}
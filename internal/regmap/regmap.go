@@ -0,0 +1,192 @@
+// Package regmap implements per register view of the registerified block.
+//
+// Functionalities carry information on how they are placed within registers.
+// Documentation generators, however, usually need the reverse information,
+// which functionalities are placed within a given register.
+package regmap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// Field represents part of a functionality item placed within single register.
+type Field struct {
+	Name string // Functionality name, params and returns names are prefixed with the proc or stream name.
+	Type string // Functionality type.
+
+	Writable bool // True if the field is written by the software, for example config.
+
+	IsArray   bool
+	Idx       int64 // Item index, valid only if IsArray is true.
+	ItemWidth int64
+
+	Bit   int64 // Start bit within the register.
+	Width int64 // Number of bits within the register.
+	Shift int64 // Start bit within the item.
+}
+
+// EndBit returns end bit of the field within the register.
+func (f Field) EndBit() int64 {
+	return f.Bit + f.Width - 1
+}
+
+// Label returns field label, for example, "c[2][7:4]".
+// The item bit range is omitted if the whole item is placed within the register.
+func (f Field) Label() string {
+	label := f.Name
+	if f.IsArray {
+		label += fmt.Sprintf("[%d]", f.Idx)
+	}
+	if f.Width != f.ItemWidth {
+		label += fmt.Sprintf("[%d:%d]", f.Shift+f.Width-1, f.Shift)
+	}
+	return label
+}
+
+// Register represents single register of the block.
+type Register struct {
+	Addr    int64    // Address relative to the block start address.
+	Fields  []Field  // Fields sorted by start bit.
+	Strobes []string // Strobes generated by the register access, for example, "p call".
+}
+
+// Gaps returns unoccupied bit ranges of the register with given width.
+// Ranges are sorted by start bit.
+func (r Register) Gaps(width int64) []types.SingleRange {
+	gaps := []types.SingleRange{}
+	bit := int64(0)
+	for _, f := range r.Fields {
+		if f.Bit > bit {
+			gaps = append(gaps, types.SingleRange{Start: bit, End: f.Bit - 1})
+		}
+		bit = f.EndBit() + 1
+	}
+	if bit < width {
+		gaps = append(gaps, types.SingleRange{Start: bit, End: width - 1})
+	}
+	return gaps
+}
+
+// WritableFuncs returns names of functionalities with writable fields in the register,
+// in the order of fields.
+// Params are reported as the proc or stream they belong to.
+func (r Register) WritableFuncs() []string {
+	funcs := []string{}
+	seen := map[string]bool{}
+	for _, f := range r.Fields {
+		if !f.Writable {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Name, ".")
+		if !seen[name] {
+			seen[name] = true
+			funcs = append(funcs, name)
+		}
+	}
+	return funcs
+}
+
+type builder struct {
+	regs map[int64]*Register
+}
+
+func (b *builder) reg(addr int64) *Register {
+	r, ok := b.regs[addr]
+	if !ok {
+		r = &Register{Addr: addr}
+		b.regs[addr] = r
+	}
+	return r
+}
+
+func (b *builder) add(name, typ string, f fn.Func, acs types.Access, writable bool) {
+	for idx := int64(0); idx < acs.ItemCount; idx++ {
+		for _, c := range acs.Chunks(idx) {
+			r := b.reg(c.Addr)
+			r.Fields = append(r.Fields, Field{
+				Name:      name,
+				Type:      typ,
+				Writable:  writable,
+				IsArray:   f.IsArray,
+				Idx:       idx,
+				ItemWidth: acs.ItemWidth,
+				Bit:       c.Bit,
+				Width:     c.Width,
+				Shift:     c.Shift,
+			})
+		}
+	}
+}
+
+func (b *builder) strobe(addr int64, name string) {
+	r := b.reg(addr)
+	r.Strobes = append(r.Strobes, name)
+}
+
+// Make returns registers occupied by the block own functionalities.
+// Registers of subblocks are not included.
+// Registers are sorted by address.
+func Make(blk *fn.Block) []Register {
+	b := builder{regs: map[int64]*Register{}}
+
+	for _, c := range blk.Configs {
+		b.add(c.Name, "config", c.Func, c.Access, c.IsWritable())
+	}
+	for _, i := range blk.Irqs {
+		b.add(i.Name, "irq", i.Func, i.Access, false)
+		if i.AddEnable {
+			b.add(i.Name+".enable", "irq", i.Func, i.EnableAccess, true)
+		}
+		if i.ClearAddr != nil {
+			for r := range i.Access.RegCount {
+				b.strobe(*i.ClearAddr+r, i.Name+" clear")
+			}
+		}
+	}
+	for _, m := range blk.Masks {
+		b.add(m.Name, "mask", m.Func, m.Access, m.IsWritable())
+	}
+	for _, p := range blk.Procs {
+		for _, pp := range p.Params {
+			b.add(p.Name+"."+pp.Name, "param", pp.Func, pp.Access, true)
+		}
+		for _, r := range p.Returns {
+			b.add(p.Name+"."+r.Name, "return", r.Func, r.Access, false)
+		}
+		if p.CallAddr != nil {
+			b.strobe(*p.CallAddr, p.Name+" call")
+		}
+		if p.ExitAddr != nil {
+			b.strobe(*p.ExitAddr, p.Name+" exit")
+		}
+	}
+	for _, s := range blk.Statics {
+		b.add(s.Name, "static", s.Func, s.Access, false)
+	}
+	for _, s := range blk.Statuses {
+		b.add(s.Name, "status", s.Func, s.Access, false)
+	}
+	for _, s := range blk.Streams {
+		for _, p := range s.Params {
+			b.add(s.Name+"."+p.Name, "param", p.Func, p.Access, true)
+		}
+		for _, r := range s.Returns {
+			b.add(s.Name+"."+r.Name, "return", r.Func, r.Access, false)
+		}
+		b.strobe(s.StbAddr, s.Name+" strobe")
+	}
+
+	regs := make([]Register, 0, len(b.regs))
+	for _, r := range b.regs {
+		sort.SliceStable(r.Fields, func(i, j int) bool { return r.Fields[i].Bit < r.Fields[j].Bit })
+		regs = append(regs, *r)
+	}
+	sort.Slice(regs, func(i, j int) bool { return regs[i].Addr < regs[j].Addr })
+
+	return regs
+}
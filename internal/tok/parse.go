@@ -127,6 +127,8 @@ func Parse(src []byte, path string) ([]Token, error) {
 			tok = parseLogicalOr(&ctx)
 		} else if b == '|' {
 			tok = parseBitOr(&ctx)
+		} else if b == '^' {
+			tok = parseXor(&ctx)
 		} else if b == '"' {
 			tok, err = parseString(&ctx)
 		} else if (b == 'b' || b == 'B') && nb == '"' {
@@ -501,6 +503,12 @@ func parseBitOr(ctx *context) BitOr {
 	return bo
 }
 
+func parseXor(ctx *context) Xor {
+	x := Xor{ctx.pos()}
+	ctx.idx++
+	return x
+}
+
 func parseString(ctx *context) (String, error) {
 	t := String{ctx.pos()}
 
@@ -961,10 +969,18 @@ func parseKeyword(word []byte, ctx *context) Token {
 		return Config{pos}
 	case "const":
 		return Const{pos}
+	case "else":
+		return Else{pos}
 	case "import":
 		return Import{pos}
+	case "in":
+		return In{pos}
+	case "field":
+		return Field{pos}
 	case "group":
 		return Group{pos}
+	case "if":
+		return If{pos}
 	case "irq":
 		return Irq{pos}
 	case "mask":
@@ -1003,20 +1019,28 @@ func parseProperty(word []byte, ctx *context) Token {
 		return Access{pos}
 	case "add-enable":
 		return AddEnable{pos}
+	case "addr":
+		return Addr{pos}
 	case "align":
 		return Align{pos}
 	case "atomic":
 		return Atomic{pos}
+	case "base-address":
+		return BaseAddress{pos}
 	case "byte-write-enable":
 		return ByteWriteEnable{pos}
 	case "clear":
 		return Clear{pos}
 	case "delay":
 		return Delay{pos}
+	case "enabled":
+		return Enabled{pos}
 	case "enable-init-value":
 		return EnableInitValue{pos}
 	case "enable-reset-value":
 		return EnableResetValue{pos}
+	case "forbid-rmw":
+		return ForbidRmw{pos}
 	case "init-value":
 		return InitValue{pos}
 	case "in-trigger":
@@ -1025,6 +1049,8 @@ func parseProperty(word []byte, ctx *context) Token {
 		return Masters{pos}
 	case "out-trigger":
 		return OutTrigger{pos}
+	case "packing":
+		return Packing{pos}
 	case "range":
 		return Range{pos}
 	case "read-latency":
@@ -1037,6 +1063,8 @@ func parseProperty(word []byte, ctx *context) Token {
 		return ResetValue{pos}
 	case "size":
 		return Size{pos}
+	case "values":
+		return Values{pos}
 	case "virtual":
 		return Virtual{pos}
 	case "width":
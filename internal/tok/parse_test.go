@@ -350,6 +350,32 @@ func TestParse(t *testing.T) {
 				Eof{position{start: 11, end: 11, line: 1, column: 12}},
 			},
 		},
+		{
+			25,
+			"width = 16 if SMALL else 32",
+			[]Token{
+				Width{position{start: 0, end: 4, line: 1, column: 1}},
+				Ass{position{start: 6, end: 6, line: 1, column: 7}},
+				Int{position{start: 8, end: 9, line: 1, column: 9}},
+				If{position{start: 11, end: 12, line: 1, column: 12}},
+				Ident{position{start: 14, end: 18, line: 1, column: 15}},
+				Else{position{start: 20, end: 23, line: 1, column: 21}},
+				Int{position{start: 25, end: 26, line: 1, column: 26}},
+				Eof{position{start: 27, end: 27, line: 1, column: 28}},
+			},
+		},
+		{
+			26,
+			"A in [1]",
+			[]Token{
+				Ident{position{start: 0, end: 0, line: 1, column: 1}},
+				In{position{start: 2, end: 3, line: 1, column: 3}},
+				LBracket{position{start: 5, end: 5, line: 1, column: 6}},
+				Int{position{start: 6, end: 6, line: 1, column: 7}},
+				RBracket{position{start: 7, end: 7, line: 1, column: 8}},
+				Eof{position{start: 8, end: 8, line: 1, column: 9}},
+			},
+		},
 	}
 
 	for i, test := range tests {
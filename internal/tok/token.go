@@ -91,12 +91,16 @@ type (
 	Colon     struct{ position } // :
 	// Keyword tokens
 	Const  struct{ position }
+	Else   struct{ position }
+	If     struct{ position }
 	Import struct{ position }
+	In     struct{ position } // Membership operator
 	Type   struct{ position }
 	// Functionality tokens
 	Block  struct{ position }
 	Bus    struct{ position }
 	Config struct{ position }
+	Field  struct{ position }
 	Group  struct{ position }
 	Irq    struct{ position }
 	Mask   struct{ position }
@@ -110,23 +114,29 @@ type (
 	// Property tokens
 	Access           struct{ position }
 	AddEnable        struct{ position }
+	Addr             struct{ position }
 	Align            struct{ position }
 	Atomic           struct{ position }
+	BaseAddress      struct{ position }
 	ByteWriteEnable  struct{ position }
 	Clear            struct{ position }
 	Delay            struct{ position }
+	Enabled          struct{ position }
 	EnableInitValue  struct{ position }
 	EnableResetValue struct{ position }
+	ForbidRmw        struct{ position }
 	InitValue        struct{ position }
 	InTrigger        struct{ position }
 	Masters          struct{ position }
 	OutTrigger       struct{ position }
+	Packing          struct{ position }
 	Range            struct{ position }
 	ReadLatency      struct{ position }
 	ReadValue        struct{ position }
 	Reset            struct{ position }
 	ResetValue       struct{ position }
 	Size             struct{ position }
+	Values           struct{ position }
 	Virtual          struct{ position }
 	Width            struct{ position }
 	// Currently unused tokens
@@ -166,7 +176,8 @@ func (bs BitString) Name() string { return "bit string" }
 
 func (t Time) Name() string { return "time" }
 
-func (n Neg) Name() string { return "!" }
+func (n Neg) Name() string    { return "'!'" }
+func (n Neg) Precedence() int { return 7 }
 
 func (a Ass) Name() string { return "'='" }
 
@@ -244,8 +255,15 @@ func (c Colon) Precedence() int { return 0 }
 
 func (c Const) Name() string { return "'const'" }
 
+func (e Else) Name() string { return "'else'" }
+
+func (i If) Name() string { return "'if'" }
+
 func (i Import) Name() string { return "'import'" }
 
+func (i In) Name() string    { return "'in'" }
+func (i In) Precedence() int { return 3 }
+
 func (t Type) Name() string { return "'type'" }
 
 func (b Block) Name() string { return "'block'" }
@@ -259,6 +277,9 @@ func (b Bus) functionality() {}
 func (c Config) Name() string   { return "'config'" }
 func (c Config) functionality() {}
 
+func (f Field) Name() string   { return "'field'" }
+func (f Field) functionality() {}
+
 func (g Group) Name() string   { return "'group'" }
 func (g Group) functionality() {}
 
@@ -295,12 +316,18 @@ func (a Access) property()    {}
 func (ae AddEnable) Name() string { return "'add-enable'" }
 func (ae AddEnable) property()    {}
 
+func (a Addr) Name() string { return "'addr'" }
+func (a Addr) property()    {}
+
 func (a Align) Name() string { return "'align'" }
 func (a Align) property()    {}
 
 func (a Atomic) Name() string { return "'atomic'" }
 func (a Atomic) property()    {}
 
+func (ba BaseAddress) Name() string { return "'base-address'" }
+func (ba BaseAddress) property()    {}
+
 func (bwe ByteWriteEnable) Name() string { return "'byte-write-enable'" }
 func (bwe ByteWriteEnable) property()    {}
 
@@ -310,6 +337,9 @@ func (c Clear) property()    {}
 func (d Delay) Name() string { return "'delay'" }
 func (d Delay) property()    {}
 
+func (e Enabled) Name() string { return "'enabled'" }
+func (e Enabled) property()    {}
+
 func (eiv EnableInitValue) Name() string { return "'enable-init-value'" }
 func (eiv EnableInitValue) property()    {}
 
@@ -322,9 +352,15 @@ func (iv InitValue) property()    {}
 func (it InTrigger) Name() string { return "'in-trigger'" }
 func (it InTrigger) property()    {}
 
+func (fr ForbidRmw) Name() string { return "'forbid-rmw'" }
+func (fr ForbidRmw) property()    {}
+
 func (m Masters) Name() string { return "'masters'" }
 func (m Masters) property()    {}
 
+func (p Packing) Name() string { return "'packing'" }
+func (p Packing) property()    {}
+
 func (ot OutTrigger) Name() string { return "'out-trigger'" }
 func (ot OutTrigger) property()    {}
 
@@ -346,6 +382,9 @@ func (rv ResetValue) property()    {}
 func (s Size) Name() string { return "'size'" }
 func (s Size) property()    {}
 
+func (v Values) Name() string { return "'values'" }
+func (v Values) property()    {}
+
 func (v Virtual) Name() string { return "'virtual'" }
 func (v Virtual) property()    {}
 
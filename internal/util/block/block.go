@@ -1,6 +1,7 @@
 package block
 
 import (
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/group"
 	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
 )
 
@@ -9,6 +10,7 @@ func AddConfig(b *fn.Block, c *fn.Config)      { b.Configs = append(b.Configs, c
 func AddGroup(b *fn.Block, g *fn.Group)        { b.Groups = append(b.Groups, g) }
 func AddIrq(b *fn.Block, i *fn.Irq)            { b.Irqs = append(b.Irqs, i) }
 func AddMask(b *fn.Block, m *fn.Mask)          { b.Masks = append(b.Masks, m) }
+func AddMemory(b *fn.Block, m *fn.Memory)      { b.Memories = append(b.Memories, m) }
 func AddProc(b *fn.Block, f *fn.Proc)          { b.Procs = append(b.Procs, f) }
 func AddStatic(b *fn.Block, s *fn.Static)      { b.Statics = append(b.Statics, s) }
 func AddStatus(b *fn.Block, s *fn.Status)      { b.Statuses = append(b.Statuses, s) }
@@ -21,11 +23,21 @@ func HasFunctionality(blk *fn.Block, name string) bool {
 			return true
 		}
 	}
+	for i := range blk.Groups {
+		if blk.Groups[i].Name == name || group.HasFunctionality(blk.Groups[i], name) {
+			return true
+		}
+	}
 	for i := range blk.Masks {
 		if blk.Masks[i].Name == name {
 			return true
 		}
 	}
+	for i := range blk.Memories {
+		if blk.Memories[i].Name == name {
+			return true
+		}
+	}
 	for i := range blk.Procs {
 		if blk.Procs[i].Name == name {
 			return true
@@ -54,3 +66,33 @@ func HasFunctionality(blk *fn.Block, name string) bool {
 
 	return false
 }
+
+// OwnFunctionalities returns functionalities occupying the block own registers.
+// Groups, blackboxes, memories and subblocks are not included.
+func OwnFunctionalities(blk *fn.Block) []fn.Functionality {
+	fns := []fn.Functionality{}
+
+	for _, c := range blk.Configs {
+		fns = append(fns, c)
+	}
+	for _, i := range blk.Irqs {
+		fns = append(fns, i)
+	}
+	for _, m := range blk.Masks {
+		fns = append(fns, m)
+	}
+	for _, p := range blk.Procs {
+		fns = append(fns, p)
+	}
+	for _, s := range blk.Statics {
+		fns = append(fns, s)
+	}
+	for _, s := range blk.Statuses {
+		fns = append(fns, s)
+	}
+	for _, s := range blk.Streams {
+		fns = append(fns, s)
+	}
+
+	return fns
+}
@@ -7,6 +7,9 @@ import (
 
 // HasConst returns true if container already has constant with given name.
 func HasConst(c cnst.Container, name string) bool {
+	if _, ok := c.BitStrs[name]; ok {
+		return true
+	}
 	if _, ok := c.Bools[name]; ok {
 		return true
 	}
@@ -29,7 +32,7 @@ func HasConst(c cnst.Container, name string) bool {
 func AddConst(c *cnst.Container, name string, v val.Value) {
 	switch v.(type) {
 	case val.BitStr:
-		panic("not yet implemented")
+		addBitStrConst(c, name, v)
 	case val.Bool:
 		addBoolConst(c, name, v)
 	case val.Float:
@@ -56,6 +59,14 @@ func AddConst(c *cnst.Container, name string, v val.Value) {
 	}
 }
 
+func addBitStrConst(c *cnst.Container, name string, v val.Value) {
+	bs := string(v.(val.BitStr))
+	if c.BitStrs == nil {
+		c.BitStrs = map[string]string{name: bs}
+	}
+	c.BitStrs[name] = bs
+}
+
 func addBoolConst(c *cnst.Container, name string, v val.Value) {
 	b := bool(v.(val.Bool))
 	if c.Bools == nil {
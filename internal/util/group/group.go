@@ -7,28 +7,42 @@ import (
 )
 
 func HasFunctionality(grp *fn.Group, name string) bool {
-	for i := range grp.Configs {
-		if grp.Configs[i].Name == name {
+	for _, f := range Functionalities(grp) {
+		if f.GetName() == name {
 			return true
 		}
 	}
-	for i := range grp.Masks {
-		if grp.Masks[i].Name == name {
-			return true
-		}
+
+	return false
+}
+
+// Functionalities returns all functionalities of the group.
+func Functionalities(grp *fn.Group) []fn.Functionality {
+	fns := []fn.Functionality{}
+
+	for _, c := range grp.Configs {
+		fns = append(fns, c)
 	}
-	for i := range grp.Statics {
-		if grp.Statics[i].Name == name {
-			return true
-		}
+	for _, i := range grp.Irqs {
+		fns = append(fns, i)
 	}
-	for i := range grp.Statuses {
-		if grp.Statuses[i].Name == name {
-			return true
-		}
+	for _, m := range grp.Masks {
+		fns = append(fns, m)
+	}
+	for _, p := range grp.Params {
+		fns = append(fns, p)
+	}
+	for _, r := range grp.Returns {
+		fns = append(fns, r)
+	}
+	for _, s := range grp.Statics {
+		fns = append(fns, s)
+	}
+	for _, s := range grp.Statuses {
+		fns = append(fns, s)
 	}
 
-	return false
+	return fns
 }
 
 func IsEmpty(grp fn.Group) bool {
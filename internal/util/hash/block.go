@@ -30,6 +30,10 @@ func hashBlock(b *fn.Block) uint32 {
 	for _, c := range b.Configs {
 		write(&buf, Hash(c))
 	}
+	// Groups
+	for _, g := range b.Groups {
+		write(&buf, Hash(g))
+	}
 	// Irqs
 	for _, i := range b.Irqs {
 		write(&buf, Hash(i))
@@ -38,6 +42,10 @@ func hashBlock(b *fn.Block) uint32 {
 	for _, m := range b.Masks {
 		write(&buf, Hash(m))
 	}
+	// Memories
+	for _, m := range b.Memories {
+		write(&buf, Hash(m))
+	}
 	// Procs
 	for _, p := range b.Procs {
 		write(&buf, Hash(p))
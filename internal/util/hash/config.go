@@ -22,6 +22,20 @@ func hashConfig(c *fn.Config) uint32 {
 	// Width
 	write(&buf, c.Width)
 
+	// Values
+	for _, ev := range c.Values {
+		write(&buf, ev.Name)
+		write(&buf, ev.Value)
+	}
+
+	// AccessMode
+	write(&buf, c.AccessMode)
+
+	// Fields
+	for _, f := range c.Fields {
+		write(&buf, Hash(f))
+	}
+
 	// Access
 	write(&buf, Hash(c.Access))
 
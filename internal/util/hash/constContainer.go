@@ -12,8 +12,15 @@ import (
 func hashConstContainer(c *cnst.Container) uint32 {
 	buf := bytes.Buffer{}
 
+	// BitStrs
+	keys := maps.Keys(c.BitStrs)
+	sort.Strings(keys)
+	for _, key := range keys {
+		buf.Write([]byte(c.BitStrs[key]))
+	}
+
 	// Bools
-	keys := maps.Keys(c.Bools)
+	keys = maps.Keys(c.Bools)
 	sort.Strings(keys)
 	for _, key := range keys {
 		write(&buf, c.Bools[key])
@@ -0,0 +1,23 @@
+package hash
+
+import (
+	"bytes"
+	"hash/adler32"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+func hashField(f *fn.Field) uint32 {
+	buf := bytes.Buffer{}
+
+	// Func
+	write(&buf, Hash(&f.Func))
+
+	// Offset
+	write(&buf, f.Offset)
+
+	// Width
+	write(&buf, f.Width)
+
+	return adler32.Checksum(buf.Bytes())
+}
@@ -0,0 +1,28 @@
+package hash
+
+import (
+	"bytes"
+	"hash/adler32"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/internal/util/group"
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+// hashGroup hashes only group membership.
+// Group functionalities are hashed as block functionalities.
+func hashGroup(g *fn.Group) uint32 {
+	buf := bytes.Buffer{}
+
+	// Func
+	write(&buf, Hash(&g.Func))
+
+	// Virtual
+	write(&buf, g.Virtual)
+
+	// Functionalities
+	for _, f := range group.Functionalities(g) {
+		write(&buf, f.GetName())
+	}
+
+	return adler32.Checksum(buf.Bytes())
+}
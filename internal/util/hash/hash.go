@@ -42,12 +42,18 @@ func Hash(data any) uint32 {
 		return hashBlock(d)
 	case *fn.Config:
 		return hashConfig(d)
+	case *fn.Field:
+		return hashField(d)
 	case *cnst.Container:
 		return hashConstContainer(d)
+	case *fn.Group:
+		return hashGroup(d)
 	case *fn.Irq:
 		return hashIrq(d)
 	case *fn.Mask:
 		return hashMask(d)
+	case *fn.Memory:
+		return hashMemory(d)
 	case *fn.Proc:
 		return hashProc(d)
 	case *fn.Param:
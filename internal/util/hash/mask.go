@@ -22,6 +22,9 @@ func hashMask(m *fn.Mask) uint32 {
 	// Width
 	write(&buf, m.Width)
 
+	// AccessMode
+	write(&buf, m.AccessMode)
+
 	// Access
 	write(&buf, Hash(m.Access))
 
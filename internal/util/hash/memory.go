@@ -0,0 +1,38 @@
+package hash
+
+import (
+	"bytes"
+	"hash/adler32"
+
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/fn"
+)
+
+func hashMemory(m *fn.Memory) uint32 {
+	buf := bytes.Buffer{}
+
+	// Func
+	write(&buf, Hash(&m.Func))
+
+	// Size
+	write(&buf, m.Size)
+
+	// Width
+	write(&buf, m.Width)
+
+	// Access
+	write(&buf, m.Access)
+
+	// ByteWriteEnable
+	write(&buf, m.ByteWriteEnable)
+
+	// ReadLatency
+	write(&buf, m.ReadLatency)
+
+	// Sizes
+	write(&buf, Hash(m.Sizes))
+
+	// AddrSpace
+	write(&buf, Hash(m.AddrSpace))
+
+	return adler32.Checksum(buf.Bytes())
+}
@@ -16,6 +16,12 @@ func hashParam(p *fn.Param) uint32 {
 	// Width
 	write(&buf, p.Width)
 
+	// Values
+	for _, ev := range p.Values {
+		write(&buf, ev.Name)
+		write(&buf, ev.Value)
+	}
+
 	// Access
 	write(&buf, Hash(p.Access))
 
@@ -19,6 +19,11 @@ func hashStatic(s *fn.Static) uint32 {
 	// Width
 	write(&buf, s.Width)
 
+	// Fields
+	for _, f := range s.Fields {
+		write(&buf, Hash(f))
+	}
+
 	// Access
 	write(&buf, Hash(s.Access))
 
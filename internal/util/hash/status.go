@@ -19,6 +19,17 @@ func hashStatus(s *fn.Status) uint32 {
 	// Width
 	write(&buf, s.Width)
 
+	// Values
+	for _, ev := range s.Values {
+		write(&buf, ev.Name)
+		write(&buf, ev.Value)
+	}
+
+	// Fields
+	for _, f := range s.Fields {
+		write(&buf, Hash(f))
+	}
+
 	// Access
 	write(&buf, Hash(s.Access))
 
@@ -9,7 +9,7 @@ const RepoIssueUrl = "https://github.com/Functional-Bus-Description-Language/go-
 
 func IsBaseType(t string) bool {
 	baseTypes := [...]string{
-		"blackbox", "block", "bus", "config", "group", "irq", "mask", "param", "proc", "return", "static", "status", "stream",
+		"blackbox", "block", "bus", "config", "field", "group", "irq", "mask", "memory", "param", "proc", "return", "static", "status", "stream",
 	}
 
 	for i := range baseTypes {
@@ -24,19 +24,21 @@ func IsBaseType(t string) bool {
 // IsValidProperty returns true if given property is valid for given base type.
 func IsValidProperty(p string, t string) error {
 	validProps := map[string][]string{
-		"blackbox": []string{"size"},
-		"block":    []string{"align", "masters", "reset"},
-		"bus":      []string{"align", "masters", "reset", "width"},
-		"config":   []string{"atomic", "init-value", "range", "read-value", "reset-value", "width"},
-		"group":    []string{"virtual"},
-		"irq":      []string{"add-enable", "clear", "enable-init-value", "enable-reset-value", "in-trigger", "out-trigger"},
-		"mask":     []string{"atomic", "init-value", "read-value", "reset-value", "width"},
-		"param":    []string{"range", "width"},
-		"proc":     []string{"delay"},
-		"return":   []string{"width"},
-		"static":   []string{"init-value", "read-value", "reset-value", "width"},
-		"status":   []string{"atomic", "read-value", "width"},
-		"stream":   []string{"delay"},
+		"blackbox": []string{"addr", "enabled", "size"},
+		"block":    []string{"addr", "align", "enabled", "masters", "packing", "reset"},
+		"bus":      []string{"addr", "align", "base-address", "forbid-rmw", "masters", "packing", "reset", "width"},
+		"config":   []string{"access", "addr", "atomic", "enabled", "init-value", "range", "read-value", "reset-value", "values", "width"},
+		"field":    []string{"enabled", "width"},
+		"group":    []string{"enabled", "virtual"},
+		"irq":      []string{"add-enable", "clear", "enable-init-value", "enable-reset-value", "enabled", "in-trigger", "out-trigger"},
+		"mask":     []string{"access", "addr", "atomic", "enabled", "init-value", "read-value", "reset-value", "width"},
+		"memory":   []string{"access", "addr", "byte-write-enable", "enabled", "read-latency", "size", "width"},
+		"param":    []string{"enabled", "range", "values", "width"},
+		"proc":     []string{"delay", "enabled"},
+		"return":   []string{"enabled", "width"},
+		"static":   []string{"addr", "enabled", "init-value", "read-value", "reset-value", "width"},
+		"status":   []string{"addr", "atomic", "enabled", "read-value", "values", "width"},
+		"stream":   []string{"delay", "enabled"},
 	}
 
 	if list, ok := validProps[t]; ok {
@@ -71,17 +73,19 @@ func IsValidProperty(p string, t string) error {
 func IsValidInnerType(it string, ot string) bool {
 	validTypes := map[string][]string{
 		"blackbox": []string{},
-		"block":    []string{"blackbox", "block", "config", "group", "irq", "mask", "proc", "static", "status", "stream"},
-		"bus":      []string{"blackbox", "block", "config", "group", "irq", "mask", "proc", "static", "status", "stream"},
-		"config":   []string{},
+		"block":    []string{"blackbox", "block", "config", "group", "irq", "mask", "memory", "proc", "static", "status", "stream"},
+		"bus":      []string{"blackbox", "block", "config", "group", "irq", "mask", "memory", "proc", "static", "status", "stream"},
+		"config":   []string{"field"},
+		"field":    []string{},
 		"group":    []string{"config", "irq", "mask", "param", "return", "static", "status"},
 		"irq":      []string{},
 		"mask":     []string{},
+		"memory":   []string{},
 		"param":    []string{},
 		"proc":     []string{"param", "return"},
 		"return":   []string{},
-		"static":   []string{},
-		"status":   []string{},
+		"static":   []string{"field"},
+		"status":   []string{"field"},
 		"stream":   []string{"param", "return"},
 	}
 
@@ -41,6 +41,71 @@ func (bs BitStr) CharWidth() int64 {
 	return int64(len(bs)) - 3
 }
 
+// ToBin converts bit string to the binary bit string.
+// Meta values are replicated, for example, x"U" is converted to b"UUUU".
+func (bs BitStr) ToBin() BitStr {
+	if bs[0] == 'b' {
+		return bs
+	}
+
+	s := make([]byte, bs.BitWidth()+3)
+	s[0] = 'b'
+	s[1] = '"'
+
+	chunkStart := int64(0)
+	chunkWidth := int64(4)
+	if bs[0] == 'o' {
+		chunkStart = 1
+		chunkWidth = 3
+	}
+
+	for i := range bs.CharWidth() {
+		var chunk [4]byte
+		char := bs[2+i]
+		switch char {
+		case '1':
+			chunk = [4]byte{'0', '0', '0', '1'}
+		case '2':
+			chunk = [4]byte{'0', '0', '1', '0'}
+		case '3':
+			chunk = [4]byte{'0', '0', '1', '1'}
+		case '4':
+			chunk = [4]byte{'0', '1', '0', '0'}
+		case '5':
+			chunk = [4]byte{'0', '1', '0', '1'}
+		case '6':
+			chunk = [4]byte{'0', '1', '1', '0'}
+		case '7':
+			chunk = [4]byte{'0', '1', '1', '1'}
+		case '8':
+			chunk = [4]byte{'1', '0', '0', '0'}
+		case '9':
+			chunk = [4]byte{'1', '0', '0', '1'}
+		case 'a', 'A':
+			chunk = [4]byte{'1', '0', '1', '0'}
+		case 'b', 'B':
+			chunk = [4]byte{'1', '0', '1', '1'}
+		case 'c', 'C':
+			chunk = [4]byte{'1', '1', '0', '0'}
+		case 'd', 'D':
+			chunk = [4]byte{'1', '1', '0', '1'}
+		case 'e', 'E':
+			chunk = [4]byte{'1', '1', '1', '0'}
+		case 'f', 'F':
+			chunk = [4]byte{'1', '1', '1', '1'}
+		case '0', 'h', 'H', 'l', 'L', 'u', 'U', 'x', 'X', 'w', 'W', 'z', 'Z', '-':
+			chunk = [4]byte{char, char, char, char}
+		}
+		for j := range chunkWidth {
+			s[2+chunkWidth*i+j] = chunk[chunkStart+j]
+		}
+	}
+
+	s[len(s)-1] = '"'
+
+	return BitStr(string(s))
+}
+
 func MakeBitStr(s string) (BitStr, error) {
 	format := s[0]
 	bs := BitStr("")
@@ -149,21 +214,19 @@ func BitStrFromInt(v Int, width int64) (BitStr, error) {
 			)
 	}
 
-	if i >= 0 {
-		var s string
-		if width%4 == 0 {
-			s = fmt.Sprintf("x\"%0*x\"", width/4, i)
-		} else if width%3 == 0 {
-			s = fmt.Sprintf("o\"%0*o\"", width/3, i)
-		} else {
-			s = fmt.Sprintf("b\"%0*b\"", width, i)
-		}
-
-		return BitStr(s), nil
+	u := uint64(i)
+	if width < 64 {
+		u &= 1<<width - 1
 	}
 
-	// Negative value handling
-	panic("BitStrFromInt, negative value handling not yet implemented")
+	var s string
+	if width%4 == 0 {
+		s = fmt.Sprintf("x\"%0*x\"", width/4, u)
+	} else if width%3 == 0 {
+		s = fmt.Sprintf("o\"%0*o\"", width/3, u)
+	} else {
+		s = fmt.Sprintf("b\"%0*b\"", width, u)
+	}
 
-	//return BitStr(""), nil
+	return BitStr(s), nil
 }
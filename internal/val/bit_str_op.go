@@ -0,0 +1,165 @@
+package val
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bit string operations work on the binary representation of bit strings.
+// Bits are indexed from the least significant bit, which has index 0.
+//
+// Bitwise operations handle meta values in the same way as the VHDL std_logic operators.
+// Weak values 'H' and 'L' are treated as '1' and '0', 'U' is propagated,
+// and other meta values result in 'X', unless the result is determined by the other operand.
+
+// bits returns the binary value literal of the bit string.
+func (bs BitStr) bits() string {
+	s := string(bs.ToBin())
+	return s[2 : len(s)-1]
+}
+
+func makeBinBitStrFromBits(bits string) BitStr {
+	return BitStr("b\"" + bits + "\"")
+}
+
+// Concat returns concatenation of bit strings x and y.
+// Bits of x are the most significant bits of the result.
+func Concat(x, y BitStr) BitStr {
+	return makeBinBitStrFromBits(x.bits() + y.bits())
+}
+
+// Repeat returns bit string replicated n times.
+func (bs BitStr) Repeat(n int64) BitStr {
+	return makeBinBitStrFromBits(strings.Repeat(bs.bits(), int(n)))
+}
+
+// Slice returns bits from index l to index r inclusive.
+// It panics if l > r or the indexes are out of range.
+func (bs BitStr) Slice(l, r int64) BitStr {
+	bits := bs.bits()
+	width := int64(len(bits))
+	if l < 0 || l > r || r >= width {
+		panic(fmt.Sprintf("invalid bit string slice %d:%d, width %d", l, r, width))
+	}
+
+	return makeBinBitStrFromBits(bits[width-1-r : width-l])
+}
+
+// Int converts bit string to integer.
+// Conversion fails if the bit string contains meta values,
+// or if its value does not fit into the integer type.
+func (bs BitStr) Int() (Int, error) {
+	var v uint64
+	for _, b := range bs.bits() {
+		if b != '0' && b != '1' {
+			return 0, fmt.Errorf("cannot convert bit string %s with meta values to integer", bs)
+		}
+		if v > (1<<62)-1 {
+			return 0, fmt.Errorf("bit string %s value is too large to be converted to integer", bs)
+		}
+		v = v<<1 | uint64(b-'0')
+	}
+
+	return Int(v), nil
+}
+
+// normBit converts bit value to the uppercase and weak values 'H' and 'L' to '1' and '0'.
+func normBit(b byte) byte {
+	switch b {
+	case 'h', 'H':
+		return '1'
+	case 'l', 'L':
+		return '0'
+	case 'u':
+		return 'U'
+	}
+	return b
+}
+
+func andBit(x, y byte) byte {
+	x, y = normBit(x), normBit(y)
+	switch {
+	case x == '0' || y == '0':
+		return '0'
+	case x == 'U' || y == 'U':
+		return 'U'
+	case x == '1' && y == '1':
+		return '1'
+	}
+	return 'X'
+}
+
+func orBit(x, y byte) byte {
+	x, y = normBit(x), normBit(y)
+	switch {
+	case x == '1' || y == '1':
+		return '1'
+	case x == 'U' || y == 'U':
+		return 'U'
+	case x == '0' && y == '0':
+		return '0'
+	}
+	return 'X'
+}
+
+func xorBit(x, y byte) byte {
+	x, y = normBit(x), normBit(y)
+	switch {
+	case x == 'U' || y == 'U':
+		return 'U'
+	case (x == '0' || x == '1') && (y == '0' || y == '1'):
+		if x == y {
+			return '0'
+		}
+		return '1'
+	}
+	return 'X'
+}
+
+func notBit(x byte) byte {
+	switch normBit(x) {
+	case '0':
+		return '1'
+	case '1':
+		return '0'
+	case 'U':
+		return 'U'
+	}
+	return 'X'
+}
+
+// bitwise applies function f to bits of bit strings x and y.
+// It panics if bit strings have different widths.
+func bitwise(x, y BitStr, f func(x, y byte) byte) BitStr {
+	xb := x.bits()
+	yb := y.bits()
+	if len(xb) != len(yb) {
+		panic(fmt.Sprintf("bit strings with different widths %d and %d", len(xb), len(yb)))
+	}
+
+	bits := make([]byte, len(xb))
+	for i := range bits {
+		bits[i] = f(xb[i], yb[i])
+	}
+
+	return makeBinBitStrFromBits(string(bits))
+}
+
+// And returns bitwise and of bit strings with equal widths.
+func And(x, y BitStr) BitStr { return bitwise(x, y, andBit) }
+
+// Or returns bitwise or of bit strings with equal widths.
+func Or(x, y BitStr) BitStr { return bitwise(x, y, orBit) }
+
+// Xor returns bitwise xor of bit strings with equal widths.
+func Xor(x, y BitStr) BitStr { return bitwise(x, y, xorBit) }
+
+// Not returns bitwise negation of the bit string.
+func (bs BitStr) Not() BitStr {
+	bits := []byte(bs.bits())
+	for i := range bits {
+		bits[i] = notBit(bits[i])
+	}
+
+	return makeBinBitStrFromBits(string(bits))
+}
@@ -0,0 +1,47 @@
+package val
+
+import (
+	"testing"
+)
+
+func TestBitStrOps(t *testing.T) {
+	var tests = []struct {
+		got  BitStr
+		want BitStr
+	}{
+		{got: Concat(`x"A"`, `b"01"`), want: `b"101001"`},
+		{got: BitStr(`o"5"`).Repeat(2), want: `b"101101"`},
+		{got: BitStr(`x"A5"`).Slice(4, 7), want: `b"1010"`},
+		{got: BitStr(`x"A5"`).Slice(0, 0), want: `b"1"`},
+		{got: And(`b"01HLUX-"`, `b"1111111"`), want: `b"0110UXX"`},
+		{got: And(`b"UX-"`, `b"000"`), want: `b"000"`},
+		{got: Or(`b"01HLUX-"`, `b"0000000"`), want: `b"0110UXX"`},
+		{got: Or(`b"UX-"`, `b"111"`), want: `b"111"`},
+		{got: Xor(`b"0110U"`, `b"0101X"`), want: `b"0011U"`},
+		{got: BitStr(`b"01HLUX-"`).Not(), want: `b"1001UXX"`},
+	}
+
+	for i, test := range tests {
+		if test.got != test.want {
+			t.Errorf("[%d]: got %v, want %v", i, test.got, test.want)
+		}
+	}
+}
+
+func TestBitStrFromIntNegative(t *testing.T) {
+	got, err := BitStrFromInt(-1, 5)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if want := BitStr(`b"11111"`); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got, err = BitStrFromInt(-2, 8)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if want := BitStr(`x"fe"`); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,8 @@
 // Package val provides types for Functional Bus Description Language type system.
 package val
 
+import "fmt"
+
 type Value interface {
 	Type() string
 }
@@ -46,6 +48,23 @@ type Time struct {
 
 func (t Time) Type() string { return "time" }
 
+// String returns the time in the time literal format.
+// The greatest unit in which the time is an integer is used, for example, "20 us".
+func (t Time) String() string {
+	if t.Ns == 0 {
+		return fmt.Sprintf("%d s", t.S)
+	}
+
+	ns := t.S*1000000000 + t.Ns
+	switch {
+	case ns%1000000 == 0:
+		return fmt.Sprintf("%d ms", ns/1000000)
+	case ns%1000 == 0:
+		return fmt.Sprintf("%d us", ns/1000)
+	}
+	return fmt.Sprintf("%d ns", ns)
+}
+
 func (t *Time) Normalize() {
 	if t.Ns < 1000000000 {
 		return
@@ -54,3 +73,21 @@ func (t *Time) Normalize() {
 	t.S += t.Ns / 1000000000
 	t.Ns = t.Ns % 1000000000
 }
+
+// Equal returns true if both values are of the same type and are equal.
+func Equal(x, y Value) bool {
+	if xl, ok := x.(List); ok {
+		yl, ok := y.(List)
+		if !ok || len(xl) != len(yl) {
+			return false
+		}
+		for i := range xl {
+			if !Equal(xl[i], yl[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return x == y
+}
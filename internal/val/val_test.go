@@ -0,0 +1,23 @@
+package val
+
+import (
+	"testing"
+)
+
+func TestTimeString(t *testing.T) {
+	var tests = []struct {
+		in   Time
+		want string
+	}{
+		{in: Time{S: 2}, want: "2 s"},
+		{in: Time{S: 1, Ns: 500000000}, want: "1500 ms"},
+		{in: Time{Ns: 20000}, want: "20 us"},
+		{in: Time{Ns: 7}, want: "7 ns"},
+	}
+
+	for i, test := range tests {
+		if got := test.in.String(); got != test.want {
+			t.Errorf("[%d]: got %q, want %q", i, got, test.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cnst
 
 type Container struct {
+	BitStrs   map[string]string
 	Bools     map[string]bool
 	BoolLists map[string][]bool
 	Floats    map[string]float64
@@ -11,6 +12,9 @@ type Container struct {
 
 // Empty returns true if Container holds no constants.
 func (c *Container) Empty() bool {
+	if len(c.BitStrs) != 0 {
+		return false
+	}
 	if len(c.Bools) != 0 || len(c.BoolLists) != 0 {
 		return false
 	}
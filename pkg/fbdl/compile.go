@@ -26,7 +26,7 @@ func Compile(mainPath, mainName string, addTimestamp bool) (*fn.Block, map[strin
 		pkgs[k] = v
 	}
 
-	reg.Registerify(bus, addTimestamp)
+	reg.Registerify(bus, addTimestamp, nil)
 
 	return bus, pkgs, nil
 }
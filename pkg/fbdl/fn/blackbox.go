@@ -8,6 +8,7 @@ type Blackbox struct {
 	Func
 
 	Size int64
+	Addr *int64
 
 	Sizes     types.Sizes
 	AddrSpace types.SingleRange
@@ -13,9 +13,25 @@ type Block struct {
 	Reset   string
 	Width   int64
 
+	// Packing is the strategy of packing functionalities into registers.
+	// If not set, it is inherited from the parent block.
+	Packing string
+
+	// ForbidRMW is set with the 'forbid-rmw' property, valid only for the main bus.
+	// If true, registers cannot be shared by multiple writable functionalities.
+	ForbidRMW bool
+
+	// Addr is the fixed start address set with the 'addr' property,
+	// or with the 'base-address' property in case of the main bus.
+	// Subblock address is relative to the parent block start address.
+	Addr *int64
+
 	Sizes     types.Sizes
 	AddrSpace types.SingleRange
 
+	// Registers shared by multiple writable functionalities, writes to them require read-modify-write.
+	SharedRegs []types.SharedReg
+
 	Consts cnst.Container
 
 	Blackboxes []*Blackbox
@@ -23,6 +39,7 @@ type Block struct {
 	Groups     []*Group
 	Irqs       []*Irq
 	Masks      []*Mask
+	Memories   []*Memory
 	Procs      []*Proc
 	Statics    []*Static
 	Statuses   []*Status
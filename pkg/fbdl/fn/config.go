@@ -14,7 +14,25 @@ type Config struct {
 	ResetValue types.BitStr
 	Width      int64
 
+	// Values are named values set with the 'values' property, nil if not set.
+	Values types.Enum
+
+	// Fields are named bit fields of the item, nil if not declared.
+	Fields []*Field
+
+	Addr *int64
+
+	// AccessMode is the software access mode set with the 'access' property,
+	// "Read Write", "Read Only" or "Write Only".
+	AccessMode string
+
 	Access types.Access
 }
 
 func (c Config) Type() string { return "config" }
+
+// IsReadable returns true if the config can be read by the software.
+func (c Config) IsReadable() bool { return c.AccessMode != "Write Only" }
+
+// IsWritable returns true if the config can be written by the software.
+func (c Config) IsWritable() bool { return c.AccessMode != "Read Only" }
@@ -0,0 +1,28 @@
+package fn
+
+import (
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// Field represents named bit field within the config, static or status item.
+// Fields are placed in the declaration order, starting from the least significant bit of the item.
+// Splitting the item into fields preserves atomic access to the whole item.
+type Field struct {
+	Func
+
+	Offset int64 // Start bit within the item.
+	Width  int64
+}
+
+func (f Field) Type() string { return "field" }
+
+// EndBit returns end bit of the field within the item.
+func (f Field) EndBit() int64 {
+	return f.Offset + f.Width - 1
+}
+
+// Chunks returns chunks of the field within the item with given index.
+// Chunk Shift is relative to the field start bit.
+func (f Field) Chunks(acs types.Access, idx int64) []types.Chunk {
+	return acs.BitChunks(idx, f.Offset, f.Width)
+}
@@ -13,7 +13,19 @@ type Mask struct {
 	ResetValue types.BitStr
 	Width      int64
 
+	Addr *int64
+
+	// AccessMode is the software access mode set with the 'access' property,
+	// "Read Write", "Read Only" or "Write Only".
+	AccessMode string
+
 	Access types.Access
 }
 
 func (m Mask) Type() string { return "mask" }
+
+// IsReadable returns true if the mask can be read by the software.
+func (m Mask) IsReadable() bool { return m.AccessMode != "Write Only" }
+
+// IsWritable returns true if the mask can be written by the software.
+func (m Mask) IsWritable() bool { return m.AccessMode != "Read Only" }
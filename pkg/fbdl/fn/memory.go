@@ -0,0 +1,33 @@
+package fn
+
+import (
+	"github.com/Functional-Bus-Description-Language/go-fbdl/pkg/fbdl/types"
+)
+
+// Memory represents on-chip RAM or ROM accessible via a naturally aligned address window.
+type Memory struct {
+	Func
+
+	Size            int64 // Number of words.
+	Width           int64 // Word width in bits.
+	Access          string
+	ByteWriteEnable bool
+	ReadLatency     int64 // Number of clock cycles between the read request and valid data.
+	Addr            *int64
+
+	Sizes     types.Sizes
+	AddrSpace types.SingleRange
+}
+
+func (m Memory) Type() string { return "memory" }
+
+// RegsPerWord returns number of registers occupied by a single memory word.
+// It is rounded up to the power of 2, so that the word address decoding requires only address bits comparison.
+func (m Memory) RegsPerWord(busWidth int64) int64 {
+	regs := (m.Width + busWidth - 1) / busWidth
+	n := int64(1)
+	for n < regs {
+		n *= 2
+	}
+	return n
+}
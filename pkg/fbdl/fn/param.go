@@ -10,6 +10,9 @@ type Param struct {
 	Range types.Range
 	Width int64
 
+	// Values are named values set with the 'values' property, nil if not set.
+	Values types.Enum
+
 	Access types.Access
 }
 
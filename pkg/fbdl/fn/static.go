@@ -12,6 +12,11 @@ type Static struct {
 	ResetValue types.BitStr
 	Width      int64
 
+	// Fields are named bit fields of the item, nil if not declared.
+	Fields []*Field
+
+	Addr *int64
+
 	Access types.Access
 }
 
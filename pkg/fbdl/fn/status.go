@@ -11,6 +11,14 @@ type Status struct {
 	ReadValue types.BitStr
 	Width     int64
 
+	// Values are named values set with the 'values' property, nil if not set.
+	Values types.Enum
+
+	// Fields are named bit fields of the item, nil if not declared.
+	Fields []*Field
+
+	Addr *int64
+
 	Access types.Access
 }
 
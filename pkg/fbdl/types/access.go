@@ -40,6 +40,91 @@ func (acs Access) AddrRange() SingleRange {
 	return SingleRange{Start: acs.StartAddr, End: acs.EndAddr}
 }
 
+// Chunk describes part of an access item placed within single register.
+// The item bits [Shift + Width - 1:Shift] are placed in the register
+// under address Addr at bits [Bit + Width - 1:Bit].
+type Chunk struct {
+	Addr  int64
+	Bit   int64
+	Width int64
+	Shift int64
+}
+
+// ItemStart returns address and bit of the first bit of the item with given index.
+func (acs Access) ItemStart(idx int64) (int64, int64) {
+	rw := acs.RegWidth
+	w := acs.ItemWidth
+
+	switch acs.Type {
+	case "SingleOneReg", "SingleNRegs":
+		return acs.StartAddr, acs.StartBit
+	case "ArrayOneReg":
+		return acs.StartAddr, acs.StartBit + idx*w
+	case "ArrayOneInReg":
+		return acs.StartAddr + idx, acs.StartBit
+	case "ArrayNRegs":
+		offset := acs.StartBit + idx*w
+		return acs.StartAddr + offset/rw, offset % rw
+	case "ArrayNInReg", "ArrayNInRegMInEndReg":
+		itemsInReg := rw / w
+		return acs.StartAddr + idx/itemsInReg, (idx % itemsInReg) * w
+	case "ArrayOneInNRegs":
+		regsPerItem := (w + rw - 1) / rw
+		return acs.StartAddr + idx*regsPerItem, 0
+	}
+
+	panic(fmt.Sprintf("unhandled access type '%s'", acs.Type))
+}
+
+// Chunks returns chunks of the item with given index.
+// Chunks are returned in increasing address order.
+func (acs Access) Chunks(idx int64) []Chunk {
+	if idx < 0 || idx >= acs.ItemCount {
+		panic(fmt.Sprintf("index %d out of range [0:%d]", idx, acs.ItemCount-1))
+	}
+
+	chunks := []Chunk{}
+	addr, bit := acs.ItemStart(idx)
+	for shift := int64(0); shift < acs.ItemWidth; {
+		width := acs.RegWidth - bit
+		if acs.ItemWidth-shift < width {
+			width = acs.ItemWidth - shift
+		}
+		chunks = append(chunks, Chunk{Addr: addr, Bit: bit, Width: width, Shift: shift})
+		shift += width
+		addr++
+		bit = 0
+	}
+
+	return chunks
+}
+
+// BitChunks returns chunks of the bit range [offset + width - 1:offset] of the item with given index.
+// Chunk Shift is relative to the offset.
+// Chunks are returned in increasing address order.
+func (acs Access) BitChunks(idx, offset, width int64) []Chunk {
+	if offset < 0 || offset+width > acs.ItemWidth {
+		panic(fmt.Sprintf("bit range [%d:%d] out of item range [%d:0]", offset+width-1, offset, acs.ItemWidth-1))
+	}
+
+	chunks := []Chunk{}
+	for _, c := range acs.Chunks(idx) {
+		start := max(c.Shift, offset)
+		end := min(c.Shift+c.Width, offset+width)
+		if start >= end {
+			continue
+		}
+		chunks = append(chunks, Chunk{
+			Addr:  c.Addr,
+			Bit:   c.Bit + start - c.Shift,
+			Width: end - start,
+			Shift: start - offset,
+		})
+	}
+
+	return chunks
+}
+
 // SingleOneReg describes an access to a single functionality placed within single register.
 //
 //	Example:
@@ -440,3 +440,66 @@ func TestMakeArrayOneInReg(t *testing.T) {
 		}
 	}
 }
+
+func TestChunks(t *testing.T) {
+	var tests = []struct {
+		acs  Access
+		idx  int64
+		want []Chunk
+	}{
+		{MakeSingleAccess(2, 5, 10), 0, []Chunk{{2, 5, 10, 0}}},
+		{MakeSingleAccess(2, 20, 40), 0, []Chunk{{2, 20, 12, 0}, {3, 0, 28, 12}}},
+		{MakeArrayOneRegAccess(3, 1, 2, 8), 2, []Chunk{{1, 18, 8, 0}}},
+		{MakeArrayOneInRegAccess(3, 4, 5, 21), 1, []Chunk{{5, 5, 21, 0}}},
+		{MakeArrayNRegsAccess(3, 0, 10, 20), 1, []Chunk{{0, 30, 2, 0}, {1, 0, 18, 2}}},
+		{MakeArrayNInRegAccess(6, 7, 15), 3, []Chunk{{8, 15, 15, 0}}},
+		{MakeArrayNInRegMInEndRegAccess(5, 0, 15), 4, []Chunk{{2, 0, 15, 0}}},
+		{MakeArrayOneInNRegsAccess(2, 0, 33), 1, []Chunk{{2, 0, 32, 0}, {3, 0, 1, 32}}},
+	}
+
+	for i, test := range tests {
+		got := test.acs.Chunks(test.idx)
+
+		if len(got) != len(test.want) {
+			t.Errorf("[%d] got %v, want %v", i, got, test.want)
+			continue
+		}
+		for j := range got {
+			if got[j] != test.want[j] {
+				t.Errorf("[%d] got %v, want %v", i, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestBitChunks(t *testing.T) {
+	var tests = []struct {
+		acs    Access
+		idx    int64
+		offset int64
+		width  int64
+		want   []Chunk
+	}{
+		{MakeSingleAccess(2, 5, 10), 0, 3, 4, []Chunk{{2, 8, 4, 0}}},
+		{MakeSingleAccess(2, 20, 40), 0, 8, 8, []Chunk{{2, 28, 4, 0}, {3, 0, 4, 4}}},
+		{MakeSingleAccess(2, 20, 40), 0, 12, 28, []Chunk{{3, 0, 28, 0}}},
+		{MakeArrayOneRegAccess(3, 1, 2, 8), 2, 7, 1, []Chunk{{1, 25, 1, 0}}},
+		{MakeArrayOneInNRegsAccess(2, 0, 33), 1, 30, 3, []Chunk{{2, 30, 2, 0}, {3, 0, 1, 2}}},
+	}
+
+	for i, test := range tests {
+		got := test.acs.BitChunks(test.idx, test.offset, test.width)
+
+		if len(got) != len(test.want) {
+			t.Errorf("[%d] got %v, want %v", i, got, test.want)
+			continue
+		}
+		for j := range got {
+			if got[j] != test.want[j] {
+				t.Errorf("[%d] got %v, want %v", i, got, test.want)
+				break
+			}
+		}
+	}
+}
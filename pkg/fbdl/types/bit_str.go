@@ -59,7 +59,7 @@ func (bs BitStr) Extend(width int64) BitStr {
 	case 'b':
 		return extendBin(bs, width)
 	case 'o':
-		panic("not yet implemented")
+		return extendOctal(bs, width)
 	case 'x':
 		return extendHex(bs, width)
 	default:
@@ -87,20 +87,20 @@ func extendBin(bs BitStr, width int64) BitStr {
 	return BitStr(string(s))
 }
 
-func extendHex(bs BitStr, width int64) BitStr {
+func extendOctal(bs BitStr, width int64) BitStr {
 	bitWidthDiff := width - bs.BitWidth()
 
-	if bitWidthDiff%4 == 0 {
-		s := make([]byte, width/4+3)
+	if bitWidthDiff%3 == 0 {
+		s := make([]byte, width/3+3)
 
-		s[0] = 'x'
+		s[0] = 'o'
 		s[1] = '"'
 
-		for i := range bitWidthDiff / 4 {
+		for i := range bitWidthDiff / 3 {
 			s[2+i] = '0'
 		}
 		for i := range bs.CharWidth() {
-			s[2+bitWidthDiff/4+i] = string(bs)[2+i]
+			s[2+bitWidthDiff/3+i] = string(bs)[2+i]
 		}
 
 		s[len(s)-1] = '"'
@@ -110,67 +110,34 @@ func extendHex(bs BitStr, width int64) BitStr {
 	return extendBin(bs.ToBin(), width)
 }
 
-func (bs BitStr) ToBin() BitStr {
-	if bs.IsBin() {
-		return bs
-	}
+func extendHex(bs BitStr, width int64) BitStr {
+	bitWidthDiff := width - bs.BitWidth()
 
-	s := make([]byte, bs.BitWidth()+3)
-	s[0] = 'b'
-	s[1] = '"'
+	if bitWidthDiff%4 == 0 {
+		s := make([]byte, width/4+3)
 
-	chunkStart := int64(0)
-	chunkWidth := int64(4)
-	if bs.IsOctal() {
-		chunkStart = 1
-		chunkWidth = 3
-	}
+		s[0] = 'x'
+		s[1] = '"'
 
-	for i := range bs.CharWidth() {
-		var chunk [4]byte
-		char := bs[2+i]
-		switch char {
-		case '1':
-			chunk = [4]byte{'0', '0', '0', '1'}
-		case '2':
-			chunk = [4]byte{'0', '0', '1', '0'}
-		case '3':
-			chunk = [4]byte{'0', '0', '1', '1'}
-		case '4':
-			chunk = [4]byte{'0', '1', '0', '0'}
-		case '5':
-			chunk = [4]byte{'0', '1', '0', '1'}
-		case '6':
-			chunk = [4]byte{'0', '1', '1', '0'}
-		case '7':
-			chunk = [4]byte{'0', '1', '1', '1'}
-		case '8':
-			chunk = [4]byte{'1', '0', '0', '0'}
-		case '9':
-			chunk = [4]byte{'1', '0', '0', '1'}
-		case 'a', 'A':
-			chunk = [4]byte{'1', '0', '1', '0'}
-		case 'b', 'B':
-			chunk = [4]byte{'1', '0', '1', '1'}
-		case 'c', 'C':
-			chunk = [4]byte{'1', '1', '0', '0'}
-		case 'd', 'D':
-			chunk = [4]byte{'1', '1', '0', '1'}
-		case 'e', 'E':
-			chunk = [4]byte{'1', '1', '1', '0'}
-		case 'f', 'F':
-			chunk = [4]byte{'1', '1', '1', '1'}
-		case '0', 'h', 'H', 'l', 'L', 'u', 'U', 'x', 'X', 'w', 'W', 'z', 'Z', '-':
-			chunk = [4]byte{char, char, char, char}
+		for i := range bitWidthDiff / 4 {
+			s[2+i] = '0'
 		}
-		for j := range chunkWidth {
-			s[2+chunkWidth*i+j] = chunk[chunkStart+j]
+		for i := range bs.CharWidth() {
+			s[2+bitWidthDiff/4+i] = string(bs)[2+i]
 		}
+
+		s[len(s)-1] = '"'
+		return BitStr(string(s))
 	}
 
-	s[len(s)-1] = '"'
+	return extendBin(bs.ToBin(), width)
+}
 
-	return BitStr(string(s))
+// ToBin converts bit string to the binary bit string.
+// Meta values are replicated, for example, x"U" is converted to b"UUUU".
+func (bs BitStr) ToBin() BitStr {
+	valBs := val.BitStr(string(bs))
+	return BitStr(string(valBs.ToBin()))
 }
 
 // Uint64 converts bit string to uint64.
@@ -54,6 +54,27 @@ func TestExtendBin(t *testing.T) {
 	}
 }
 
+func TestExtendOctal(t *testing.T) {
+	var tests = []struct {
+		in            BitStr
+		extendedWidth int64
+		want          BitStr
+	}{
+		{in: BitStr(`o"7"`), extendedWidth: 6, want: BitStr(`o"07"`)},
+		{in: BitStr(`o"12"`), extendedWidth: 9, want: BitStr(`o"012"`)},
+		{in: BitStr(`o"7"`), extendedWidth: 5, want: BitStr(`b"00111"`)},
+		{in: BitStr(`o"x"`), extendedWidth: 4, want: BitStr(`b"0xxx"`)},
+	}
+
+	for i, test := range tests {
+		got := test.in.Extend(test.extendedWidth)
+
+		if got != test.want {
+			t.Errorf("[%d]: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
 func TestExtendHex(t *testing.T) {
 	var tests = []struct {
 		in            BitStr
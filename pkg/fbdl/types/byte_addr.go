@@ -0,0 +1,63 @@
+package types
+
+// RegByteCount returns the number of bytes occupied by a single register of the given width.
+// The width is rounded up to full bytes, and the number of bytes is rounded up to the power of 2,
+// so that registers are naturally aligned in the byte address space.
+// For example, registers of width 12 occupy 2 bytes, and registers of width 24 occupy 4 bytes.
+func RegByteCount(width int64) int64 {
+	bytes := (width + 7) / 8
+	count := int64(1)
+	for count < bytes {
+		count *= 2
+	}
+	return count
+}
+
+// ByteAddr returns byte address of the register with the given address.
+func ByteAddr(addr, width int64) int64 {
+	return addr * RegByteCount(width)
+}
+
+// ByteRange returns byte address range of the given registers address range.
+// The end of the returned range is the last byte of the last register.
+func ByteRange(r SingleRange, width int64) SingleRange {
+	n := RegByteCount(width)
+	return SingleRange{Start: r.Start * n, End: r.End*n + n - 1}
+}
+
+// ByteLanes returns byte lanes mask of bits from startBit to endBit within the register.
+// Bit i of the mask is set if byte i of the register is used.
+func ByteLanes(startBit, endBit int64) int64 {
+	mask := int64(0)
+	for b := startBit / 8; b <= endBit/8; b++ {
+		mask |= 1 << b
+	}
+	return mask
+}
+
+// ByteAccess represents an access expressed in byte addresses.
+type ByteAccess struct {
+	StartAddr int64 // Byte address of the first register.
+	EndAddr   int64 // Byte address of the last register.
+
+	StartLanes int64 // Byte lanes mask of the first register.
+	EndLanes   int64 // Byte lanes mask of the last register.
+}
+
+// ByteAccess returns the access expressed in byte addresses.
+func (acs Access) ByteAccess() ByteAccess {
+	ba := ByteAccess{
+		StartAddr: ByteAddr(acs.StartAddr, acs.RegWidth),
+		EndAddr:   ByteAddr(acs.EndAddr, acs.RegWidth),
+	}
+
+	if acs.RegCount == 1 {
+		ba.StartLanes = ByteLanes(acs.StartBit, acs.EndBit)
+		ba.EndLanes = ba.StartLanes
+	} else {
+		ba.StartLanes = ByteLanes(acs.StartBit, acs.StartBit+acs.StartRegWidth-1)
+		ba.EndLanes = ByteLanes(acs.EndBit-acs.EndRegWidth+1, acs.EndBit)
+	}
+
+	return ba
+}
@@ -0,0 +1,54 @@
+package types
+
+import "testing"
+
+func TestRegByteCount(t *testing.T) {
+	var tests = []struct {
+		width int64
+		want  int64
+	}{
+		{1, 1},
+		{8, 1},
+		{9, 2},
+		{12, 2},
+		{16, 2},
+		{24, 4},
+		{32, 4},
+		{33, 8},
+		{64, 8},
+	}
+
+	for i, test := range tests {
+		if got := RegByteCount(test.width); got != test.want {
+			t.Fatalf("%d: got %d, want %d", i, got, test.want)
+		}
+	}
+}
+
+func TestByteAccess(t *testing.T) {
+	busWidth = 32
+
+	var tests = []struct {
+		acs  Access
+		want ByteAccess
+	}{
+		{
+			MakeSingleOneRegAccess(3, 4, 8),
+			ByteAccess{StartAddr: 12, EndAddr: 12, StartLanes: 0b0011, EndLanes: 0b0011},
+		},
+		{
+			MakeSingleNRegsAccess(1, 20, 20),
+			ByteAccess{StartAddr: 4, EndAddr: 8, StartLanes: 0b1100, EndLanes: 0b0001},
+		},
+		{
+			MakeArrayOneInRegAccess(3, 2, 16, 9),
+			ByteAccess{StartAddr: 8, EndAddr: 16, StartLanes: 0b1100, EndLanes: 0b1100},
+		},
+	}
+
+	for i, test := range tests {
+		if got := test.acs.ByteAccess(); got != test.want {
+			t.Fatalf("%d: got %+v, want %+v", i, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package types
+
+// EnumValue represents single named value of the functionality.
+type EnumValue struct {
+	Name  string
+	Value int64
+}
+
+// Enum represents named values of the functionality set with the 'values' property.
+// Values are stored in the declaration order.
+type Enum []EnumValue
+
+// Name returns name of the given value.
+// The second return is false if the value is not named.
+func (e Enum) Name(v int64) (string, bool) {
+	for _, ev := range e {
+		if ev.Value == v {
+			return ev.Name, true
+		}
+	}
+	return "", false
+}
+
+// MaxValue returns the greatest value of the enum.
+func (e Enum) MaxValue() int64 {
+	m := int64(0)
+	for _, ev := range e {
+		m = max(m, ev.Value)
+	}
+	return m
+}
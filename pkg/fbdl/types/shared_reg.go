@@ -0,0 +1,9 @@
+package types
+
+// SharedReg represents a register shared by multiple writable functionalities.
+// Writing any of the functionalities requires read-modify-write operation,
+// as values of the remaining ones must be preserved.
+type SharedReg struct {
+	Addr  int64    // Address relative to the block start address.
+	Funcs []string // Names of functionalities sharing the register.
+}
@@ -0,0 +1,71 @@
+#!/bin/bash
+
+update=false
+
+help_msg="Script for managing documentation generation tests.
+Must be run from the project's root.
+
+Tests are placed in the tests/documentation/<format>/ directory.
+Each test directory mirrors path of the registerification test with the bus description,
+for example tests/documentation/md/proc/only_params/two_in_single_reg uses
+the tests/registerification/proc/only_params/two_in_single_reg/bus.fbd file.
+The generated documentation is compared with the doc.golden file.
+
+Usage:
+  scripts/doc-tests.sh <command>
+
+Commands:
+  help    Display help message.
+  run     Run tests.
+  update  Run tests discarding errors and update golden files using generated files.
+
+If no command is provided the run is assumed.
+"
+
+while true ; do
+	case "$1" in
+		help) printf "$help_msg" ; exit 0 ;;
+		run) shift ;;
+		update) update=true ; shift ;;
+		"") shift ; break ;;
+		*) echo "invalid argument '$1'" ; exit 1 ;;
+	esac
+done
+
+if ! $update; then
+	set -e
+fi
+
+root=$(pwd)
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+cd tests/documentation/
+
+echo -e "\nRunning documentation generation tests\n"
+
+for dir in $(find . -maxdepth 4 -mindepth 4 -type d | sort);
+do
+	testname=`basename $dir`
+	# Ignore tests starting with '_' character.
+	if [ ${testname::1} = "_" ]; then
+		continue
+	fi
+
+	echo "  $dir"
+	format=$(echo "$dir" | cut -d/ -f2)
+	regtest=$(echo "$dir" | cut -d/ -f3-)
+	out="$tmp/$format/$regtest/doc"
+	mkdir -p "$(dirname "$out")"
+	(cd "$root/tests/registerification/$regtest" && "$root/fbdl" doc -format "$format" -o "$out" bus.fbd)
+	diff --color "$dir/doc.golden" "$out"
+	if $update; then
+		cp "$out" "$dir/doc.golden"
+	fi
+done
+
+if $update; then
+	echo -e "\ngolden files updated\n"
+else
+	echo -e "\nAll \e[1;32mPASSED\e[0m!"
+fi
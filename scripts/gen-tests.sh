@@ -0,0 +1,94 @@
+#!/bin/bash
+
+update=false
+
+help_msg="Script for managing code generation tests.
+Must be run from the project's root.
+
+Tests are placed in the tests/generation/<target>/ directory.
+Each test directory mirrors path of the registerification test with the bus description,
+for example tests/generation/python/proc/only_params/two_in_single_reg uses
+the tests/registerification/proc/only_params/two_in_single_reg/bus.fbd file.
+Only files present in the test directory are compared with the generated files.
+Golden files have the .golden suffix, so that they are not treated as source files.
+Generated code is also checked with the target toolchain.
+
+Usage:
+  scripts/gen-tests.sh <command>
+
+Commands:
+  help    Display help message.
+  run     Run tests.
+  update  Run tests discarding errors and update golden files using generated files.
+
+If no command is provided the run is assumed.
+"
+
+while true ; do
+	case "$1" in
+		help) printf "$help_msg" ; exit 0 ;;
+		run) shift ;;
+		update) update=true ; shift ;;
+		"") shift ; break ;;
+		*) echo "invalid argument '$1'" ; exit 1 ;;
+	esac
+done
+
+if ! $update; then
+	set -e
+fi
+
+root=$(pwd)
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+# check checks generated code with the target toolchain.
+check() {
+	local target=$1
+	local dir=$2
+
+	case "$target" in
+		go)
+			printf "module gentest\n\ngo 1.22\n" > "$dir/go.mod"
+			(cd "$dir" && go vet ./...)
+			;;
+		python) (cd "$dir" && python3 -c "import bus") ;;
+		rust) (cd "$dir/bus" && RUSTFLAGS="-D warnings" cargo check --offline --quiet --target-dir "$tmp/target") ;;
+		*) echo "unknown target '$target'" ; return 1 ;;
+	esac
+}
+
+cd tests/generation/
+
+echo -e "\nRunning code generation tests\n"
+
+for dir in $(find . -maxdepth 4 -mindepth 4 -type d | sort);
+do
+	testname=`basename $dir`
+	# Ignore tests starting with '_' character.
+	if [ ${testname::1} = "_" ]; then
+		continue
+	fi
+
+	echo "  $dir"
+	target=$(echo "$dir" | cut -d/ -f2)
+	regtest=$(echo "$dir" | cut -d/ -f3-)
+	out="$tmp/$target/$regtest"
+	mkdir -p "$out"
+	(cd "$root/tests/registerification/$regtest" && "$root/fbdl" gen "$target" -o "$out/bus" bus.fbd)
+	for golden in $(cd "$dir" && find . -type f -name "*.golden" | sort);
+	do
+		file=${golden%.golden}
+		diff --color "$dir/$golden" "$out/bus/$file"
+		if $update; then
+			cp "$out/bus/$file" "$dir/$golden"
+		fi
+	done
+	check "$target" "$out"
+done
+
+if $update; then
+	echo -e "\ngolden files updated\n"
+else
+	echo -e "\nAll \e[1;32mPASSED\e[0m!"
+fi
@@ -5,13 +5,17 @@ update=false
 help_msg="Script for managing registerification tests.
 Must be run from the project's root.
 
+Tests with the stderr.golden file check registerification errors,
+the standard error output is compared instead of the registerification results.
+
 Usage:
   scripts/reg-tests.sh <command>
 
 Commands:
   help    Display help message.
   run     Run tests.
-  update  Run tests discarding errors and update golden.json files using reg.json files.
+  update  Run tests discarding errors and update golden.json files using reg.json files
+          and stderr.golden files using standard error output.
 
 If no command is provided the run is assumed.
 "
@@ -44,7 +48,24 @@ do
 
 	echo "  $dir"
 	cd "$dir"
-	../../../../../fbdl bus.fbd > reg.json
+	if [ -f stderr.golden ]; then
+		../../../../../fbdl bus.fbd > /dev/null 2>stderr || true
+		diff --color stderr.golden stderr
+		if $update; then
+			cp stderr stderr.golden
+		fi
+		rm stderr
+		cd ../../..
+		continue
+	fi
+	# Tests with a lock file check addresses restoration, the golden lock file is not modified.
+	if [ -f fbdl.lock ]; then
+		cp fbdl.lock reg.lock
+		../../../../../fbdl -lock reg.lock bus.fbd > reg.json
+		rm reg.lock
+	else
+		../../../../../fbdl bus.fbd > reg.json
+	fi
 	diff --color golden.json reg.json
 	if $update; then
 		cp reg.json golden.json
@@ -54,7 +75,7 @@ do
 done
 
 if $update; then
-	echo -e "\ngolden.json files updated\n"
+	echo -e "\ngolden files updated\n"
 else
 	echo -e "\nAll \e[1;32mPASSED\e[0m!"
 fi